@@ -0,0 +1,209 @@
+// Package cyclonedx converts modules into CycloneDX software bills of materials and vice versa,
+// see https://cyclonedx.org/docs/1.5/json/.
+//
+// Only the subset of the CycloneDX JSON format required to represent modules is supported.
+package cyclonedx
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+const (
+	// BOMFormat is the value of the bomFormat field of every CycloneDX BOM.
+	BOMFormat = "CycloneDX"
+	// SpecVersion is the CycloneDX specification version produced by this package.
+	SpecVersion = "1.5"
+
+	// ComponentTypeLibrary is the component type used for modules.
+	ComponentTypeLibrary = "library"
+
+	// PropertyVersionSchema is the property holding the module version schema.
+	PropertyVersionSchema = "opendependency:version:schema"
+	// PropertyVersionReplaces is the property holding a replaced module version. It is repeated for each version.
+	PropertyVersionReplaces = "opendependency:version:replaces"
+)
+
+// BOM is a CycloneDX bill of materials.
+type BOM struct {
+	BOMFormat    string       `json:"bomFormat"`
+	SpecVersion  string       `json:"specVersion"`
+	Version      int          `json:"version"`
+	Metadata     *Metadata    `json:"metadata,omitempty"`
+	Components   []Component  `json:"components,omitempty"`
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+
+	// unsupported lists the JSON members without corresponding field.
+	unsupported []string
+}
+
+// Metadata describes the subject of a BOM.
+type Metadata struct {
+	Component *Component `json:"component,omitempty"`
+
+	// unsupported lists the JSON members without corresponding field.
+	unsupported []string
+}
+
+// Component is a software component described by a BOM.
+type Component struct {
+	Type       string     `json:"type"`
+	BOMRef     string     `json:"bom-ref,omitempty"`
+	Group      string     `json:"group,omitempty"`
+	Name       string     `json:"name"`
+	Version    string     `json:"version,omitempty"`
+	PURL       string     `json:"purl,omitempty"`
+	Properties []Property `json:"properties,omitempty"`
+	// Components lists nested components. They are not produced by FromModule.
+	Components []Component `json:"components,omitempty"`
+
+	// unsupported lists the JSON members without corresponding field.
+	unsupported []string
+}
+
+// Property is a name-value pair attached to a component.
+// Names may occur multiple times.
+type Property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Dependency lists the components the referenced component depends on.
+type Dependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// FromModule converts the module into a BOM.
+//
+// The module becomes the root component in the metadata and each dependency a component.
+// Components are referenced by their package URL. UPSTREAM dependencies are listed
+// as dependencies of the root component, whereas DOWNSTREAM dependencies depend on the root component.
+// Annotations become properties; the version schema and the replaced versions
+// are kept as properties named PropertyVersionSchema and PropertyVersionReplaces.
+func FromModule(module *v1.Module) (*BOM, error) {
+	if module == nil {
+		return nil, errors.New("module must not be nil")
+	}
+	if err := module.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	root := rootComponent(module)
+
+	components := make(map[string]Component)
+	var dependsOn []string
+	dependents := make(map[string]bool)
+	for _, dependency := range module.GetDependencies() {
+		component := dependencyComponent(dependency)
+		components[component.BOMRef] = component
+
+		if dependency.GetDirection() == v1.DependencyDirection_DOWNSTREAM {
+			dependents[component.BOMRef] = true
+		} else {
+			dependsOn = append(dependsOn, component.BOMRef)
+		}
+	}
+
+	bom := &BOM{
+		BOMFormat:   BOMFormat,
+		SpecVersion: SpecVersion,
+		Version:     1,
+		Metadata:    &Metadata{Component: &root},
+	}
+
+	for _, ref := range sortedRefs(components) {
+		bom.Components = append(bom.Components, components[ref])
+	}
+
+	bom.Dependencies = append(bom.Dependencies, Dependency{Ref: root.BOMRef, DependsOn: unique(dependsOn)})
+	for _, ref := range sortedRefs(components) {
+		if dependents[ref] {
+			bom.Dependencies = append(bom.Dependencies, Dependency{Ref: ref, DependsOn: []string{root.BOMRef}})
+		}
+	}
+
+	return bom, nil
+}
+
+// WriteJSON converts the module into a BOM and writes it as indented JSON.
+func WriteJSON(w io.Writer, module *v1.Module) error {
+	bom, err := FromModule(module)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(bom)
+}
+
+func rootComponent(module *v1.Module) Component {
+	purl := module.PackageURL()
+	purl.Qualifiers = nil
+
+	component := Component{
+		Type:    ComponentTypeLibrary,
+		BOMRef:  purl.String(),
+		Group:   module.GetNamespace(),
+		Name:    module.GetName(),
+		Version: module.GetVersion().GetName(),
+		PURL:    purl.String(),
+	}
+
+	annotations := module.GetAnnotations()
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		component.Properties = append(component.Properties, Property{Name: k, Value: annotations[k]})
+	}
+
+	if module.GetVersion().Schema != nil {
+		component.Properties = append(component.Properties, Property{Name: PropertyVersionSchema, Value: module.GetVersion().GetSchema()})
+	}
+	for _, replaces := range module.GetVersion().GetReplaces() {
+		component.Properties = append(component.Properties, Property{Name: PropertyVersionReplaces, Value: replaces})
+	}
+
+	return component
+}
+
+func dependencyComponent(dependency *v1.ModuleDependency) Component {
+	purl := dependency.PackageURL().String()
+	return Component{
+		Type:    ComponentTypeLibrary,
+		BOMRef:  purl,
+		Group:   dependency.GetNamespace(),
+		Name:    dependency.GetName(),
+		Version: dependency.GetVersion(),
+		PURL:    purl,
+	}
+}
+
+func sortedRefs(components map[string]Component) []string {
+	refs := make([]string, 0, len(components))
+	for ref := range components {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// unique returns the sorted values without duplicates.
+func unique(values []string) []string {
+	sort.Strings(values)
+	var result []string
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
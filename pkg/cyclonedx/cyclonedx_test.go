@@ -0,0 +1,140 @@
+package cyclonedx
+
+import (
+	"bytes"
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func module() *v1.Module {
+	schema := v1.VersionSchemaSemVer
+	return &v1.Module{
+		Namespace: "com.example",
+		Name:      "app",
+		Type:      "go",
+		Version: &v1.ModuleVersion{
+			Name:     "v1.1.0",
+			Schema:   &schema,
+			Replaces: []string{"v1.0.0"},
+		},
+		Annotations: map[string]string{"team": "core", "license": "MIT"},
+		Dependencies: []*v1.ModuleDependency{
+			{Namespace: "org.other", Name: "log.v2", Type: "go", Version: "v2.0.0"},
+			{Namespace: "com.example", Name: "lib-core", Type: "go", Version: "v1.2.0"},
+			{Namespace: "com.example", Name: "lib-core", Type: "go", Version: "v1.2.0"},
+			{Namespace: "com.example", Name: "cli", Type: "go", Version: "v1.0.0", Direction: v1.DependencyDirection_DOWNSTREAM.Enum()},
+		},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	want := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {
+    "component": {
+      "type": "library",
+      "bom-ref": "pkg:go/com.example/app@v1.1.0",
+      "group": "com.example",
+      "name": "app",
+      "version": "v1.1.0",
+      "purl": "pkg:go/com.example/app@v1.1.0",
+      "properties": [
+        {
+          "name": "license",
+          "value": "MIT"
+        },
+        {
+          "name": "team",
+          "value": "core"
+        },
+        {
+          "name": "opendependency:version:schema",
+          "value": "semver"
+        },
+        {
+          "name": "opendependency:version:replaces",
+          "value": "v1.0.0"
+        }
+      ]
+    }
+  },
+  "components": [
+    {
+      "type": "library",
+      "bom-ref": "pkg:go/com.example/cli@v1.0.0",
+      "group": "com.example",
+      "name": "cli",
+      "version": "v1.0.0",
+      "purl": "pkg:go/com.example/cli@v1.0.0"
+    },
+    {
+      "type": "library",
+      "bom-ref": "pkg:go/com.example/lib-core@v1.2.0",
+      "group": "com.example",
+      "name": "lib-core",
+      "version": "v1.2.0",
+      "purl": "pkg:go/com.example/lib-core@v1.2.0"
+    },
+    {
+      "type": "library",
+      "bom-ref": "pkg:go/org.other/log.v2@v2.0.0",
+      "group": "org.other",
+      "name": "log.v2",
+      "version": "v2.0.0",
+      "purl": "pkg:go/org.other/log.v2@v2.0.0"
+    }
+  ],
+  "dependencies": [
+    {
+      "ref": "pkg:go/com.example/app@v1.1.0",
+      "dependsOn": [
+        "pkg:go/com.example/lib-core@v1.2.0",
+        "pkg:go/org.other/log.v2@v2.0.0"
+      ]
+    },
+    {
+      "ref": "pkg:go/com.example/cli@v1.0.0",
+      "dependsOn": [
+        "pkg:go/com.example/app@v1.1.0"
+      ]
+    }
+  ]
+}
+`
+
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		if err := WriteJSON(&buf, module()); err != nil {
+			t.Fatalf("WriteJSON() error = %v", err)
+		}
+		if got := buf.String(); got != want {
+			t.Fatalf("WriteJSON() =\n%s\nwant\n%s", got, want)
+		}
+	}
+}
+
+func TestFromModule(t *testing.T) {
+	invalid := module()
+	invalid.Name = "App"
+
+	tests := []struct {
+		name    string
+		module  *v1.Module
+		wantErr bool
+	}{
+		{"valid module", module(), false},
+		{"nil module", nil, true},
+		{"invalid module", invalid, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromModule(tt.module)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FromModule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
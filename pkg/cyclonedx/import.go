@@ -0,0 +1,217 @@
+package cyclonedx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// bomSource is the source of losses concerning the BOM itself instead of one of its components.
+const bomSource = "bom"
+
+// ReadJSON parses a CycloneDX JSON BOM and converts it into modules, see BOM.Modules.
+func ReadJSON(r io.Reader) ([]*v1.Module, *importer.Report, error) {
+	var bom BOM
+	if err := json.NewDecoder(r).Decode(&bom); err != nil {
+		return nil, nil, fmt.Errorf("cyclonedx: %w", err)
+	}
+	return bom.Modules()
+}
+
+// Modules converts the BOM into modules.
+//
+// The metadata component and all components, including nested ones, become modules, whereas
+// their coordinates are derived from the package URL or alternatively from group, name and version.
+// The dependsOn relationships become UPSTREAM dependencies. Properties become annotations,
+// except those named PropertyVersionSchema and PropertyVersionReplaces, which restore the version.
+// Identifiers are normalized to satisfy the specification constraints and all lossy transformations
+// are recorded in the returned report, including BOM, metadata and component members
+// without module equivalent like services, tools and licenses.
+func (b *BOM) Modules() ([]*v1.Module, *importer.Report, error) {
+	if b.BOMFormat != BOMFormat {
+		return nil, nil, fmt.Errorf("cyclonedx: bomFormat %q is not supported", b.BOMFormat)
+	}
+
+	i := bomImport{
+		report:  &importer.Report{},
+		modules: make(map[v1.Coordinate]*v1.Module),
+		refs:    make(map[string]*v1.Module),
+	}
+
+	for _, field := range b.unsupported {
+		i.report.Drop(bomSource, "field", field, "not supported")
+	}
+	if b.Metadata != nil {
+		for _, field := range b.Metadata.unsupported {
+			i.report.Drop(bomSource, "field", "metadata."+field, "not supported")
+		}
+		if b.Metadata.Component != nil {
+			i.addComponent(*b.Metadata.Component)
+		}
+	}
+	for _, component := range b.Components {
+		i.addComponent(component)
+	}
+	for _, dependency := range b.Dependencies {
+		i.addDependency(dependency)
+	}
+
+	return i.result, i.report, nil
+}
+
+// UnmarshalJSON decodes the BOM and remembers unsupported members, which are reported by BOM.Modules.
+func (b *BOM) UnmarshalJSON(data []byte) error {
+	type bom BOM
+	if err := json.Unmarshal(data, (*bom)(b)); err != nil {
+		return err
+	}
+
+	unsupported, err := importer.UnknownFields(data, b)
+	if err != nil {
+		return err
+	}
+	b.unsupported = unsupported
+	return nil
+}
+
+// UnmarshalJSON decodes the metadata and remembers unsupported members, which are reported by BOM.Modules.
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	type metadata Metadata
+	if err := json.Unmarshal(data, (*metadata)(m)); err != nil {
+		return err
+	}
+
+	unsupported, err := importer.UnknownFields(data, m)
+	if err != nil {
+		return err
+	}
+	m.unsupported = unsupported
+	return nil
+}
+
+// UnmarshalJSON decodes the component and remembers unsupported members, which are reported by BOM.Modules.
+func (c *Component) UnmarshalJSON(data []byte) error {
+	type component Component
+	if err := json.Unmarshal(data, (*component)(c)); err != nil {
+		return err
+	}
+
+	unsupported, err := importer.UnknownFields(data, c)
+	if err != nil {
+		return err
+	}
+	c.unsupported = unsupported
+	return nil
+}
+
+// bomImport holds the state of a BOM conversion.
+type bomImport struct {
+	report  *importer.Report
+	result  []*v1.Module
+	modules map[v1.Coordinate]*v1.Module
+	refs    map[string]*v1.Module
+}
+
+func (i *bomImport) addComponent(component Component) {
+	source := componentSource(component)
+	for _, field := range component.unsupported {
+		i.report.Drop(source, "field", field, "not supported")
+	}
+
+	coordinate, ok := i.report.Coordinate(source, component.PURL, v1.Coordinate{
+		Namespace: component.Group,
+		Name:      component.Name,
+		Version:   component.Version,
+	})
+	if !ok {
+		i.report.Drop(source, "component", component.Name, "no valid name can be derived")
+	} else if module, exists := i.modules[coordinate]; exists {
+		i.report.Drop(source, "component", coordinate.String(), "duplicate module")
+		i.addRef(component.BOMRef, module)
+	} else {
+		module := coordinate.Module()
+		i.addProperties(source, module, component.Properties)
+		i.modules[coordinate] = module
+		i.result = append(i.result, module)
+		i.addRef(component.BOMRef, module)
+	}
+
+	for _, nested := range component.Components {
+		i.addComponent(nested)
+	}
+}
+
+func (i *bomImport) addRef(ref string, module *v1.Module) {
+	if ref != "" {
+		i.refs[ref] = module
+	}
+}
+
+func (i *bomImport) addProperties(source string, module *v1.Module, properties []Property) {
+	var schema *string
+	for _, property := range properties {
+		switch property.Name {
+		case PropertyVersionSchema:
+			value := property.Value
+			schema = &value
+		case PropertyVersionReplaces:
+			if version := i.report.NormalizeVersion(source, "version replaces", property.Value); version != "" {
+				module.Version.Replaces = append(module.Version.Replaces, version)
+			}
+		default:
+			key := i.report.NormalizeIdentifier(source, "annotation key", property.Name)
+			if key == "" {
+				continue
+			}
+			if _, exists := module.GetAnnotations()[key]; exists {
+				i.report.Drop(source, "annotation", property.Name+"="+property.Value, "duplicate annotation key")
+				continue
+			}
+			if module.Annotations == nil {
+				module.Annotations = make(map[string]string)
+			}
+			module.Annotations[key] = i.report.NormalizeAnnotationValue(source, "annotation value", property.Value)
+		}
+	}
+
+	if schema != nil {
+		module.Version.Schema = schema
+		if err := module.Version.ValidateAll(); err != nil {
+			i.report.Drop(source, "version schema", *schema, err.Error())
+			module.Version.Schema = nil
+		}
+	}
+}
+
+func (i *bomImport) addDependency(dependency Dependency) {
+	module, ok := i.refs[dependency.Ref]
+	if !ok {
+		for _, ref := range dependency.DependsOn {
+			i.report.Drop(dependency.Ref, "dependency", ref, "unknown bom-ref "+dependency.Ref)
+		}
+		return
+	}
+
+	for _, ref := range dependency.DependsOn {
+		target, ok := i.refs[ref]
+		if !ok {
+			i.report.Drop(dependency.Ref, "dependency", ref, "unknown bom-ref "+ref)
+			continue
+		}
+		importer.AddDependency(module, target.Coordinate().Dependency())
+	}
+}
+
+func componentSource(component Component) string {
+	switch {
+	case component.BOMRef != "":
+		return component.BOMRef
+	case component.PURL != "":
+		return component.PURL
+	default:
+		return component.Name
+	}
+}
@@ -0,0 +1,181 @@
+package cyclonedx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func TestReadJSON_roundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, module()); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	modules, report, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if !report.Lossless() {
+		t.Errorf("ReadJSON() report =\n%s\nwant lossless", report)
+	}
+	if len(modules) != 4 {
+		t.Fatalf("ReadJSON() = %d modules, want 4", len(modules))
+	}
+
+	// dependencies are sorted and deduplicated, whereas DOWNSTREAM dependencies are declared by the dependent module
+	want := module()
+	want.Dependencies = []*v1.ModuleDependency{want.Dependencies[1], want.Dependencies[0]}
+	if !proto.Equal(modules[0], want) {
+		t.Errorf("ReadJSON() root = %v, want %v", modules[0], want)
+	}
+
+	cli := modules[1]
+	if got := cli.Coordinate().String(); got != "com.example/cli:go@v1.0.0" {
+		t.Errorf("ReadJSON() modules[1] = %v, want com.example/cli:go@v1.0.0", got)
+	}
+	if len(cli.Dependencies) != 1 || cli.Dependencies[0].Coordinate() != modules[0].Coordinate() {
+		t.Errorf("ReadJSON() modules[1].dependencies = %v, want upstream dependency to root", cli.Dependencies)
+	}
+
+	for _, m := range modules {
+		if err := m.ValidateAll(); err != nil {
+			t.Errorf("ReadJSON() module %v is invalid: %v", m.Coordinate(), err)
+		}
+	}
+}
+
+func TestReadJSON_normalization(t *testing.T) {
+	bom := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "metadata": {
+    "component": {"type": "application", "bom-ref": "root", "name": "My_App", "version": "1.0.0+build"}
+  },
+  "components": [
+    {
+      "type": "library", "bom-ref": "core", "purl": "pkg:npm/%40angular/core@16.0.0?arch=x64",
+      "licenses": [{"license": {"id": "MIT"}}],
+      "properties": [
+        {"name": "cdx:npm:package:development", "value": "true"},
+        {"name": "CDX:NPM:package:development", "value": "false"},
+        {"name": "opendependency:version:schema", "value": "calver"}
+      ],
+      "components": [
+        {"type": "library", "bom-ref": "nested", "group": "org.nested", "name": "nested", "version": "1.0"}
+      ]
+    },
+    {"type": "library", "bom-ref": "duplicate", "purl": "pkg:npm/%40angular/core@16.0.0"},
+    {"type": "library", "bom-ref": "unnamed", "name": "@@@"}
+  ],
+  "dependencies": [
+    {"ref": "root", "dependsOn": ["core", "duplicate", "missing"]},
+    {"ref": "nested", "dependsOn": ["root"]}
+  ]
+}`
+
+	modules, report, err := ReadJSON(strings.NewReader(bom))
+	if err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+
+	var got []string
+	for _, m := range modules {
+		got = append(got, m.Coordinate().String())
+		if err := m.ValidateAll(); err != nil {
+			t.Errorf("ReadJSON() module %v is invalid: %v", m.Coordinate(), err)
+		}
+	}
+	want := []string{
+		"generic/my-app:generic@1.0.0-build",
+		"angular/core:npm@16.0.0",
+		"org.nested/nested:generic@1.0",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ReadJSON() = %v, want %v", got, want)
+	}
+
+	if n := len(modules[0].Dependencies); n != 1 {
+		t.Errorf("ReadJSON() root has %d dependencies, want 1", n)
+	}
+	if got := modules[1].Annotations; len(got) != 1 || got["cdx-npm-package-development"] != "true" {
+		t.Errorf("ReadJSON() annotations = %v, want cdx-npm-package-development=true", got)
+	}
+
+	wantReport := `root: type "" changed to "generic": must be set
+root: namespace "" changed to "generic": must be set
+root: version "1.0.0+build" changed to "1.0.0-build": must be a lowercase alphanumeric version
+root: name "My_App" changed to "my-app": must be a lowercase alphanumeric identifier
+core: field "licenses" dropped: not supported
+core: purl qualifier "arch=x64" dropped: qualifiers cannot be represented
+core: namespace "@angular" changed to "angular": must be a lowercase alphanumeric identifier
+core: annotation key "cdx:npm:package:development" changed to "cdx-npm-package-development": must be a lowercase alphanumeric identifier
+core: annotation key "CDX:NPM:package:development" changed to "cdx-npm-package-development": must be a lowercase alphanumeric identifier
+core: annotation "CDX:NPM:package:development=false" dropped: duplicate annotation key
+core: version schema "calver" dropped: name: must be a calendar version like 2021.08 or 2021.08.1
+nested: type "" changed to "generic": must be set
+duplicate: namespace "@angular" changed to "angular": must be a lowercase alphanumeric identifier
+duplicate: component "angular/core:npm@16.0.0" dropped: duplicate module
+unnamed: type "" changed to "generic": must be set
+unnamed: namespace "" changed to "generic": must be set
+unnamed: version "" changed to "unknown": must be set
+unnamed: name "@@@" dropped: must be a lowercase alphanumeric identifier
+unnamed: component "@@@" dropped: no valid name can be derived
+root: dependency "missing" dropped: unknown bom-ref missing`
+	if got := report.String(); got != wantReport {
+		t.Errorf("ReadJSON() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func TestReadJSON_unsupportedMembers(t *testing.T) {
+	bom := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "metadata": {
+    "timestamp": "2021-08-30T00:00:00Z",
+    "tools": [{"name": "generator"}],
+    "component": {"type": "application", "bom-ref": "root", "purl": "pkg:golang/com.example/app@v1.0.0"}
+  },
+  "services": [{"name": "api"}],
+  "vulnerabilities": [{"id": "CVE-2021-0001"}]
+}`
+
+	modules, report, err := ReadJSON(strings.NewReader(bom))
+	if err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if len(modules) != 1 {
+		t.Errorf("ReadJSON() = %d modules, want 1", len(modules))
+	}
+
+	wantReport := `bom: field "serialNumber" dropped: not supported
+bom: field "services" dropped: not supported
+bom: field "vulnerabilities" dropped: not supported
+bom: field "metadata.timestamp" dropped: not supported
+bom: field "metadata.tools" dropped: not supported`
+	if got := report.String(); got != wantReport {
+		t.Errorf("ReadJSON() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func TestReadJSON_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		bom  string
+	}{
+		{"invalid JSON", `{`},
+		{"unsupported format", `{"bomFormat": "SPDX"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ReadJSON(strings.NewReader(tt.bom)); err == nil {
+				t.Errorf("ReadJSON() error = nil, want error")
+			}
+		})
+	}
+}
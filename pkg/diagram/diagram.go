@@ -0,0 +1,166 @@
+// Package diagram renders module dependency graphs as diagrams.
+//
+// All renderers produce deterministic output, so that diagrams can be committed and diffed.
+package diagram
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/opendependency/go-spec/pkg/graph"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Options configures the rendered diagram.
+type Options struct {
+	// LabelAnnotations lists annotation keys whose values are added to the node labels.
+	LabelAnnotations []string
+	// ColorAnnotation is the annotation key whose value is used as node colour, e.g. "#ff0000" or "red".
+	ColorAnnotation string
+	// Dangling includes modules which are referenced by dependencies but are not part of the module set.
+	Dangling bool
+}
+
+// model is the renderer independent representation of a diagram.
+type model struct {
+	namespaces []namespace
+	edges      []edge
+}
+
+type namespace struct {
+	name  string
+	nodes []node
+}
+
+type node struct {
+	coordinate v1.Coordinate
+	// module is nil for dangling references.
+	module *v1.Module
+}
+
+// edge points from the dependent module to the module it depends on.
+type edge struct {
+	from v1.Coordinate
+	to   v1.Coordinate
+	// upstream is set if the dependent module declared the dependency as UPSTREAM.
+	upstream bool
+	// downstream is set if the module depended on declared the dependency as DOWNSTREAM.
+	downstream bool
+}
+
+func newModel(modules []*v1.Module, opts Options) (*model, error) {
+	g, err := graph.New(modules)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[v1.Coordinate]node)
+	for _, n := range g.Nodes() {
+		nodes[n.Coordinate] = node{coordinate: n.Coordinate, module: n.Module}
+	}
+
+	edges := make(map[graph.Edge]*edge)
+	for _, declaration := range g.Declarations() {
+		target := declaration.Dependency.Coordinate()
+		if !g.Has(target) {
+			if !opts.Dangling {
+				continue
+			}
+			nodes[target] = node{coordinate: target}
+		}
+
+		key := declaration.Edge()
+		e, ok := edges[key]
+		if !ok {
+			e = &edge{from: key.From, to: key.To}
+			edges[key] = e
+		}
+		if declaration.Dependency.GetDirection() == v1.DependencyDirection_DOWNSTREAM {
+			e.downstream = true
+		} else {
+			e.upstream = true
+		}
+	}
+
+	m := &model{}
+
+	byNamespace := make(map[string][]node)
+	for coordinate, n := range nodes {
+		byNamespace[coordinate.Namespace] = append(byNamespace[coordinate.Namespace], n)
+	}
+	for name, namespaceNodes := range byNamespace {
+		sort.Slice(namespaceNodes, func(i, j int) bool {
+			return namespaceNodes[i].coordinate.String() < namespaceNodes[j].coordinate.String()
+		})
+		m.namespaces = append(m.namespaces, namespace{name: name, nodes: namespaceNodes})
+	}
+	sort.Slice(m.namespaces, func(i, j int) bool {
+		return m.namespaces[i].name < m.namespaces[j].name
+	})
+
+	for _, e := range edges {
+		m.edges = append(m.edges, *e)
+	}
+	sort.Slice(m.edges, func(i, j int) bool {
+		if m.edges[i].from != m.edges[j].from {
+			return m.edges[i].from.String() < m.edges[j].from.String()
+		}
+		return m.edges[i].to.String() < m.edges[j].to.String()
+	})
+
+	return m, nil
+}
+
+// labelLines returns the name, type and version as well as the selected annotations of the node.
+func (n node) labelLines(opts Options) []string {
+	lines := []string{n.coordinate.Name, n.coordinate.Type + "@" + n.coordinate.Version}
+	for _, key := range opts.LabelAnnotations {
+		if value, ok := n.module.GetAnnotations()[key]; ok {
+			lines = append(lines, key+": "+value)
+		}
+	}
+	return lines
+}
+
+// color returns the value of the colour annotation or an empty string.
+func (n node) color(opts Options) string {
+	if opts.ColorAnnotation == "" {
+		return ""
+	}
+	return n.module.GetAnnotations()[opts.ColorAnnotation]
+}
+
+func (n node) dangling() bool {
+	return n.module == nil
+}
+
+// identifier returns a deterministic identifier consisting of [A-Za-z0-9_] only.
+// All other characters and '_' itself are encoded as '_' followed by two hex digits,
+// so that different values like "a.b" and "a-b" never share an identifier.
+func identifier(prefix string, value string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('_')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+	return b.String()
+}
+
+// sanitizeColor removes all characters which are neither alphanumeric nor '#'.
+func sanitizeColor(color string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '#' {
+			return r
+		}
+		return -1
+	}, color)
+}
@@ -0,0 +1,99 @@
+package diagram
+
+import (
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func coordinate(t *testing.T, s string) v1.Coordinate {
+	t.Helper()
+	c, err := v1.ParseCoordinate(s)
+	if err != nil {
+		t.Fatalf("ParseCoordinate() error = %v", err)
+	}
+	return c
+}
+
+// modules returns a module set covering namespaces, annotations, both dependency directions and dangling references.
+func modules(t *testing.T) []*v1.Module {
+	t.Helper()
+
+	app := coordinate(t, "com.example/app:go@v1.0.0").Module()
+	app.Annotations = map[string]string{"team": `core "platform"`, "color": "lightblue"}
+	app.Dependencies = []*v1.ModuleDependency{
+		coordinate(t, "com.example/lib-core:go@v1.2.0").Dependency(),
+		coordinate(t, "org.other/log.v2:go@v2.0.0").Dependency(),
+		coordinate(t, "org.other/missing:npm@1.0.0").Dependency(),
+	}
+
+	lib := coordinate(t, "com.example/lib-core:go@v1.2.0").Module()
+	downstream := coordinate(t, "com.example/app:go@v1.0.0").Dependency()
+	downstream.Direction = v1.DependencyDirection_DOWNSTREAM.Enum()
+	lib.Dependencies = []*v1.ModuleDependency{downstream}
+
+	log := coordinate(t, "org.other/log.v2:go@v2.0.0").Module()
+	cli := coordinate(t, "org.other/cli:go@v1.0.0").Dependency()
+	cli.Direction = v1.DependencyDirection_DOWNSTREAM.Enum()
+	log.Dependencies = []*v1.ModuleDependency{cli}
+
+	return []*v1.Module{
+		log,
+		app,
+		lib,
+		coordinate(t, "org.other/cli:go@v1.0.0").Module(),
+	}
+}
+
+func Test_newModel(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           Options
+		wantNamespaces int
+		wantNodes      int
+		wantEdges      int
+	}{
+		{"without dangling", Options{}, 2, 4, 3},
+		{"with dangling", Options{Dangling: true}, 2, 5, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newModel(modules(t), tt.opts)
+			if err != nil {
+				t.Fatalf("newModel() error = %v", err)
+			}
+			nodes := 0
+			for _, ns := range m.namespaces {
+				nodes += len(ns.nodes)
+			}
+			if len(m.namespaces) != tt.wantNamespaces || nodes != tt.wantNodes || len(m.edges) != tt.wantEdges {
+				t.Errorf("newModel() = %d namespaces, %d nodes, %d edges, want %d, %d, %d",
+					len(m.namespaces), nodes, len(m.edges), tt.wantNamespaces, tt.wantNodes, tt.wantEdges)
+			}
+		})
+	}
+
+	if _, err := newModel([]*v1.Module{nil}, Options{}); err == nil {
+		t.Errorf("newModel() error = nil, want error")
+	}
+}
+
+func Test_identifier(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"abc123", "m_abc123"},
+		{"a.b", "m_a_2Eb"},
+		{"a-b", "m_a_2Db"},
+		{"a_b", "m_a_5Fb"},
+		{"a_2Eb", "m_a_5F2Eb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := identifier("m_", tt.value); got != tt.want {
+				t.Errorf("identifier() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
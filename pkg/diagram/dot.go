@@ -0,0 +1,67 @@
+package diagram
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// WriteDOT renders the modules and their dependencies as Graphviz DOT digraph.
+//
+// Modules are clustered by namespace. Edges point from the dependent module to the module it depends on
+// and are solid if declared as UPSTREAM, dashed if declared as DOWNSTREAM and bold if declared as both.
+// Dangling modules are drawn dashed.
+func WriteDOT(w io.Writer, modules []*v1.Module, opts Options) error {
+	m, err := newModel(modules, opts)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("digraph dependencies {\n")
+	bw.WriteString("\tnode [shape=box];\n")
+
+	for _, ns := range m.namespaces {
+		bw.WriteString("\n\tsubgraph " + quoteDOT("cluster_"+ns.name) + " {\n")
+		bw.WriteString("\t\tlabel=" + quoteDOT(ns.name) + ";\n")
+		for _, n := range ns.nodes {
+			attributes := []string{"label=" + quoteDOT(strings.Join(n.labelLines(opts), "\n"))}
+			if n.dangling() {
+				attributes = append(attributes, "style=dashed")
+			} else if color := n.color(opts); color != "" {
+				attributes = append(attributes, "style=filled", "fillcolor="+quoteDOT(color))
+			}
+			bw.WriteString("\t\t" + quoteDOT(n.coordinate.String()) + " [" + strings.Join(attributes, ", ") + "];\n")
+		}
+		bw.WriteString("\t}\n")
+	}
+
+	if len(m.edges) > 0 {
+		bw.WriteString("\n")
+	}
+	for _, e := range m.edges {
+		style := "solid"
+		switch {
+		case e.upstream && e.downstream:
+			style = "bold"
+		case e.downstream:
+			style = "dashed"
+		}
+		bw.WriteString("\t" + quoteDOT(e.from.String()) + " -> " + quoteDOT(e.to.String()) + " [style=" + style + "];\n")
+	}
+
+	bw.WriteString("}\n")
+
+	return bw.Flush()
+}
+
+// quoteDOT returns a double-quoted DOT string, whereas line breaks become centered line breaks.
+func quoteDOT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,42 @@
+package diagram
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	want := `digraph dependencies {
+	node [shape=box];
+
+	subgraph "cluster_com.example" {
+		label="com.example";
+		"com.example/app:go@v1.0.0" [label="app\ngo@v1.0.0\nteam: core \"platform\"", style=filled, fillcolor="lightblue"];
+		"com.example/lib-core:go@v1.2.0" [label="lib-core\ngo@v1.2.0"];
+	}
+
+	subgraph "cluster_org.other" {
+		label="org.other";
+		"org.other/cli:go@v1.0.0" [label="cli\ngo@v1.0.0"];
+		"org.other/log.v2:go@v2.0.0" [label="log.v2\ngo@v2.0.0"];
+		"org.other/missing:npm@1.0.0" [label="missing\nnpm@1.0.0", style=dashed];
+	}
+
+	"com.example/app:go@v1.0.0" -> "com.example/lib-core:go@v1.2.0" [style=bold];
+	"com.example/app:go@v1.0.0" -> "org.other/log.v2:go@v2.0.0" [style=solid];
+	"com.example/app:go@v1.0.0" -> "org.other/missing:npm@1.0.0" [style=solid];
+	"org.other/cli:go@v1.0.0" -> "org.other/log.v2:go@v2.0.0" [style=dashed];
+}
+`
+
+	opts := Options{LabelAnnotations: []string{"team", "unknown"}, ColorAnnotation: "color", Dangling: true}
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		if err := WriteDOT(&buf, modules(t), opts); err != nil {
+			t.Fatalf("WriteDOT() error = %v", err)
+		}
+		if got := buf.String(); got != want {
+			t.Fatalf("WriteDOT() =\n%s\nwant\n%s", got, want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package diagram
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// WriteMermaid renders the modules and their dependencies as Mermaid flowchart.
+//
+// Namespaces become subgraphs. Edges point from the dependent module to the module it depends on
+// and are solid if declared as UPSTREAM, dotted if declared as DOWNSTREAM and thick if declared as both.
+// Dangling modules are drawn with a dashed border.
+func WriteMermaid(w io.Writer, modules []*v1.Module, opts Options) error {
+	m, err := newModel(modules, opts)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("flowchart TB\n")
+
+	var styles []string
+	for _, ns := range m.namespaces {
+		bw.WriteString("    subgraph " + identifier("ns_", ns.name) + "[" + quoteMermaid(ns.name) + "]\n")
+		for _, n := range ns.nodes {
+			id := identifier("m_", n.coordinate.String())
+			lines := n.labelLines(opts)
+			for i, line := range lines {
+				lines[i] = quoteMermaidText(line)
+			}
+			bw.WriteString("        " + id + "[\"" + strings.Join(lines, "<br/>") + "\"]\n")
+
+			if n.dangling() {
+				styles = append(styles, "    style "+id+" stroke-dasharray: 5 5\n")
+			} else if color := sanitizeColor(n.color(opts)); color != "" {
+				styles = append(styles, "    style "+id+" fill:"+color+"\n")
+			}
+		}
+		bw.WriteString("    end\n")
+	}
+
+	for _, e := range m.edges {
+		arrow := "-->"
+		switch {
+		case e.upstream && e.downstream:
+			arrow = "==>"
+		case e.downstream:
+			arrow = "-.->"
+		}
+		bw.WriteString("    " + identifier("m_", e.from.String()) + " " + arrow + " " + identifier("m_", e.to.String()) + "\n")
+	}
+
+	for _, style := range styles {
+		bw.WriteString(style)
+	}
+
+	return bw.Flush()
+}
+
+// quoteMermaid returns a double-quoted Mermaid label.
+func quoteMermaid(s string) string {
+	return `"` + quoteMermaidText(s) + `"`
+}
+
+// quoteMermaidText replaces characters which terminate or alter Mermaid labels by entity codes.
+func quoteMermaidText(s string) string {
+	return strings.NewReplacer(
+		`"`, "#quot;",
+		"<", "#lt;",
+		">", "#gt;",
+		"\n", " ",
+	).Replace(s)
+}
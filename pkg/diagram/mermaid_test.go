@@ -0,0 +1,37 @@
+package diagram
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteMermaid(t *testing.T) {
+	want := `flowchart TB
+    subgraph ns_com_2Eexample["com.example"]
+        m_com_2Eexample_2Fapp_3Ago_40v1_2E0_2E0["app<br/>go@v1.0.0<br/>team: core #quot;platform#quot;"]
+        m_com_2Eexample_2Flib_2Dcore_3Ago_40v1_2E2_2E0["lib-core<br/>go@v1.2.0"]
+    end
+    subgraph ns_org_2Eother["org.other"]
+        m_org_2Eother_2Fcli_3Ago_40v1_2E0_2E0["cli<br/>go@v1.0.0"]
+        m_org_2Eother_2Flog_2Ev2_3Ago_40v2_2E0_2E0["log.v2<br/>go@v2.0.0"]
+        m_org_2Eother_2Fmissing_3Anpm_401_2E0_2E0["missing<br/>npm@1.0.0"]
+    end
+    m_com_2Eexample_2Fapp_3Ago_40v1_2E0_2E0 ==> m_com_2Eexample_2Flib_2Dcore_3Ago_40v1_2E2_2E0
+    m_com_2Eexample_2Fapp_3Ago_40v1_2E0_2E0 --> m_org_2Eother_2Flog_2Ev2_3Ago_40v2_2E0_2E0
+    m_com_2Eexample_2Fapp_3Ago_40v1_2E0_2E0 --> m_org_2Eother_2Fmissing_3Anpm_401_2E0_2E0
+    m_org_2Eother_2Fcli_3Ago_40v1_2E0_2E0 -.-> m_org_2Eother_2Flog_2Ev2_3Ago_40v2_2E0_2E0
+    style m_com_2Eexample_2Fapp_3Ago_40v1_2E0_2E0 fill:lightblue
+    style m_org_2Eother_2Fmissing_3Anpm_401_2E0_2E0 stroke-dasharray: 5 5
+`
+
+	opts := Options{LabelAnnotations: []string{"team", "unknown"}, ColorAnnotation: "color", Dangling: true}
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		if err := WriteMermaid(&buf, modules(t), opts); err != nil {
+			t.Fatalf("WriteMermaid() error = %v", err)
+		}
+		if got := buf.String(); got != want {
+			t.Fatalf("WriteMermaid() =\n%s\nwant\n%s", got, want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package diagram
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// WritePlantUML renders the modules and their dependencies as PlantUML component diagram.
+//
+// Namespaces become packages. Edges point from the dependent module to the module it depends on
+// and are solid if declared as UPSTREAM, dotted if declared as DOWNSTREAM and bold if declared as both.
+// Dangling modules carry the stereotype <<dangling>>.
+func WritePlantUML(w io.Writer, modules []*v1.Module, opts Options) error {
+	m, err := newModel(modules, opts)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("@startuml\n")
+
+	for _, ns := range m.namespaces {
+		bw.WriteString("package " + quotePlantUML(ns.name) + " {\n")
+		for _, n := range ns.nodes {
+			line := "  component " + quotePlantUML(strings.Join(n.labelLines(opts), "\n")) + " as " + identifier("m_", n.coordinate.String())
+			if n.dangling() {
+				line += " <<dangling>>"
+			} else if color := sanitizeColor(n.color(opts)); color != "" {
+				line += " " + "#" + strings.TrimPrefix(color, "#")
+			}
+			bw.WriteString(line + "\n")
+		}
+		bw.WriteString("}\n")
+	}
+
+	for _, e := range m.edges {
+		arrow := "-->"
+		switch {
+		case e.upstream && e.downstream:
+			arrow = "-[bold]->"
+		case e.downstream:
+			arrow = "..>"
+		}
+		bw.WriteString(identifier("m_", e.from.String()) + " " + arrow + " " + identifier("m_", e.to.String()) + "\n")
+	}
+
+	bw.WriteString("@enduml\n")
+
+	return bw.Flush()
+}
+
+// quotePlantUML returns a double-quoted PlantUML label, whereas line breaks become \n.
+// Double quotes cannot be escaped in PlantUML and are replaced by single quotes.
+func quotePlantUML(s string) string {
+	s = strings.ReplaceAll(s, `"`, `'`)
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
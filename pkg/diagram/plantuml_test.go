@@ -0,0 +1,36 @@
+package diagram
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWritePlantUML(t *testing.T) {
+	want := `@startuml
+package "com.example" {
+  component "app\ngo@v1.0.0\nteam: core 'platform'" as m_com_2Eexample_2Fapp_3Ago_40v1_2E0_2E0 #lightblue
+  component "lib-core\ngo@v1.2.0" as m_com_2Eexample_2Flib_2Dcore_3Ago_40v1_2E2_2E0
+}
+package "org.other" {
+  component "cli\ngo@v1.0.0" as m_org_2Eother_2Fcli_3Ago_40v1_2E0_2E0
+  component "log.v2\ngo@v2.0.0" as m_org_2Eother_2Flog_2Ev2_3Ago_40v2_2E0_2E0
+  component "missing\nnpm@1.0.0" as m_org_2Eother_2Fmissing_3Anpm_401_2E0_2E0 <<dangling>>
+}
+m_com_2Eexample_2Fapp_3Ago_40v1_2E0_2E0 -[bold]-> m_com_2Eexample_2Flib_2Dcore_3Ago_40v1_2E2_2E0
+m_com_2Eexample_2Fapp_3Ago_40v1_2E0_2E0 --> m_org_2Eother_2Flog_2Ev2_3Ago_40v2_2E0_2E0
+m_com_2Eexample_2Fapp_3Ago_40v1_2E0_2E0 --> m_org_2Eother_2Fmissing_3Anpm_401_2E0_2E0
+m_org_2Eother_2Fcli_3Ago_40v1_2E0_2E0 ..> m_org_2Eother_2Flog_2Ev2_3Ago_40v2_2E0_2E0
+@enduml
+`
+
+	opts := Options{LabelAnnotations: []string{"team", "unknown"}, ColorAnnotation: "color", Dangling: true}
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		if err := WritePlantUML(&buf, modules(t), opts); err != nil {
+			t.Fatalf("WritePlantUML() error = %v", err)
+		}
+		if got := buf.String(); got != want {
+			t.Fatalf("WritePlantUML() =\n%s\nwant\n%s", got, want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Query restricts transitive closure queries.
+type Query struct {
+	// MaxDepth limits the number of edges between the start module and reached modules.
+	// Zero means unlimited.
+	MaxDepth int
+	// Types restricts the reached modules to the given module types.
+	// Modules of other types are traversed but not returned. Empty means all types.
+	Types []string
+	// Namespaces restricts the reached modules to the given namespaces.
+	// Modules of other namespaces are traversed but not returned. Empty means all namespaces.
+	Namespaces []string
+}
+
+// Reach is a module reached by a transitive closure query.
+type Reach struct {
+	// Node is the reached module.
+	Node *Node
+	// Depth is the number of edges between the start module and the reached module.
+	Depth int
+	// Path is a shortest path from the start module to the reached module, both inclusive.
+	Path []v1.Coordinate
+}
+
+// TransitiveUpstream returns all modules the start module directly or indirectly depends on.
+// The result is ordered by depth and coordinate.
+func (g *Graph) TransitiveUpstream(start v1.Coordinate, query Query) ([]Reach, error) {
+	return g.closure(start, query, g.upstream)
+}
+
+// TransitiveDownstream returns all modules directly or indirectly depending on the start module,
+// i.e. all modules affected by a change of the start module.
+// The result is ordered by depth and coordinate.
+func (g *Graph) TransitiveDownstream(start v1.Coordinate, query Query) ([]Reach, error) {
+	return g.closure(start, query, g.downstream)
+}
+
+// closure traverses the adjacency breadth-first, so that each module is reached on a shortest path.
+func (g *Graph) closure(start v1.Coordinate, query Query, adjacency map[v1.Coordinate][]v1.Coordinate) ([]Reach, error) {
+	if !g.Has(start) {
+		return nil, fmt.Errorf("module %s is not part of the graph", start)
+	}
+
+	types := toSet(query.Types)
+	namespaces := toSet(query.Namespaces)
+
+	paths := map[v1.Coordinate][]v1.Coordinate{start: {start}}
+	queue := []v1.Coordinate{start}
+
+	var reaches []Reach
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		depth := len(paths[current])
+		if query.MaxDepth > 0 && depth > query.MaxDepth {
+			continue
+		}
+
+		for _, next := range adjacency[current] {
+			if _, visited := paths[next]; visited {
+				continue
+			}
+
+			path := make([]v1.Coordinate, depth, depth+1)
+			copy(path, paths[current])
+			paths[next] = append(path, next)
+			queue = append(queue, next)
+
+			if (len(types) == 0 || types[next.Type]) && (len(namespaces) == 0 || namespaces[next.Namespace]) {
+				reaches = append(reaches, Reach{Node: g.nodes[next], Depth: depth, Path: paths[next]})
+			}
+		}
+	}
+
+	sort.SliceStable(reaches, func(i, j int) bool {
+		if reaches[i].Depth != reaches[j].Depth {
+			return reaches[i].Depth < reaches[j].Depth
+		}
+		return lessCoordinate(reaches[i].Node.Coordinate, reaches[j].Node.Coordinate)
+	})
+
+	return reaches, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func reaches(reaches []Reach) string {
+	var s []string
+	for _, reach := range reaches {
+		var path []string
+		for _, c := range reach.Path {
+			path = append(path, c.Name)
+		}
+		s = append(s, strings.Join(path, ">"))
+	}
+	return strings.Join(s, " ")
+}
+
+func TestGraph_TransitiveClosure(t *testing.T) {
+	g, err := New([]*v1.Module{
+		module(t, "com.example/app:go@v1.0.0",
+			upstream(t, "com.example/api:go@v1.0.0"),
+			upstream(t, "com.example/web:npm@v1.0.0"),
+		),
+		module(t, "com.example/web:npm@v1.0.0", upstream(t, "com.example/auth:go@v1.2.0")),
+		module(t, "com.example/api:go@v1.0.0", upstream(t, "com.example/auth:go@v1.2.0")),
+		module(t, "com.example/auth:go@v1.2.0", upstream(t, "org.other/crypto:go@v1.0.0")),
+		module(t, "org.other/crypto:go@v1.0.0"),
+		module(t, "org.other/batch:go@v1.0.0", upstream(t, "com.example/app:go@v1.0.0")),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	auth := coordinate(t, "com.example/auth:go@v1.2.0")
+	app := coordinate(t, "com.example/app:go@v1.0.0")
+
+	tests := []struct {
+		name       string
+		downstream bool
+		start      v1.Coordinate
+		query      Query
+		want       string
+	}{
+		{"upstream", false, app, Query{}, "app>api app>web app>api>auth app>api>auth>crypto"},
+		{"upstream with max depth", false, app, Query{MaxDepth: 2}, "app>api app>web app>api>auth"},
+		{"upstream filtered by type", false, app, Query{Types: []string{"npm"}}, "app>web"},
+		{"upstream filtered by namespace", false, app, Query{Namespaces: []string{"org.other"}}, "app>api>auth>crypto"},
+		{"downstream", true, auth, Query{}, "auth>api auth>web auth>api>app auth>api>app>batch"},
+		{"downstream with max depth", true, auth, Query{MaxDepth: 1}, "auth>api auth>web"},
+		{"downstream filtered by type and namespace", true, auth, Query{Types: []string{"go"}, Namespaces: []string{"com.example"}}, "auth>api auth>api>app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Reach
+			var err error
+			if tt.downstream {
+				got, err = g.TransitiveDownstream(tt.start, tt.query)
+			} else {
+				got, err = g.TransitiveUpstream(tt.start, tt.query)
+			}
+			if err != nil {
+				t.Fatalf("closure error = %v", err)
+			}
+			if reaches(got) != tt.want {
+				t.Errorf("closure = %v, want %v", reaches(got), tt.want)
+			}
+			for _, reach := range got {
+				if reach.Depth != len(reach.Path)-1 {
+					t.Errorf("Depth = %d, want %d", reach.Depth, len(reach.Path)-1)
+				}
+			}
+		})
+	}
+
+	if _, err := g.TransitiveUpstream(coordinate(t, "org.other/missing:go@v1.0.0"), Query{}); err == nil {
+		t.Errorf("TransitiveUpstream() error = nil, want error for unknown module")
+	}
+}
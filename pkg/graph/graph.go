@@ -0,0 +1,197 @@
+// Package graph provides an in-memory dependency graph of a set of modules.
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Graph is the dependency graph of a set of modules.
+// Each dependency is resolved to a module of the set by namespace, name, type and version.
+type Graph struct {
+	nodes        map[v1.Coordinate]*Node
+	edges        map[Edge]struct{}
+	declarations []Declaration
+	upstream     map[v1.Coordinate][]v1.Coordinate
+	downstream   map[v1.Coordinate][]v1.Coordinate
+}
+
+// Node is a module of the graph.
+type Node struct {
+	// Coordinate identifies the module.
+	Coordinate v1.Coordinate
+	// Module is the module itself.
+	Module *v1.Module
+}
+
+// Edge is a dependency between two modules, independent of the module declaring it.
+type Edge struct {
+	// From is the dependent module.
+	From v1.Coordinate
+	// To is the module From depends on.
+	To v1.Coordinate
+}
+
+// Declaration is a dependency as declared by a module.
+type Declaration struct {
+	// Declarer is the module declaring the dependency.
+	Declarer v1.Coordinate
+	// Dependency is the declared dependency.
+	Dependency *v1.ModuleDependency
+}
+
+// Edge returns the edge described by the declaration.
+// An UPSTREAM dependency points from the declarer to the dependency, a DOWNSTREAM dependency vice versa.
+func (d Declaration) Edge() Edge {
+	target := d.Dependency.Coordinate()
+	if d.Dependency.GetDirection() == v1.DependencyDirection_DOWNSTREAM {
+		return Edge{From: target, To: d.Declarer}
+	}
+	return Edge{From: d.Declarer, To: target}
+}
+
+// New builds the dependency graph of the modules.
+// Every module must have a unique coordinate.
+func New(modules []*v1.Module) (*Graph, error) {
+	g := &Graph{
+		nodes:      make(map[v1.Coordinate]*Node, len(modules)),
+		edges:      make(map[Edge]struct{}),
+		upstream:   make(map[v1.Coordinate][]v1.Coordinate),
+		downstream: make(map[v1.Coordinate][]v1.Coordinate),
+	}
+
+	for i, module := range modules {
+		if module == nil {
+			return nil, fmt.Errorf("index %d: module must be set", i)
+		}
+		coordinate := module.Coordinate()
+		if _, exists := g.nodes[coordinate]; exists {
+			return nil, fmt.Errorf("index %d: duplicate module %s", i, coordinate)
+		}
+		g.nodes[coordinate] = &Node{Coordinate: coordinate, Module: module}
+	}
+
+	for _, node := range g.Nodes() {
+		for i, dependency := range node.Module.GetDependencies() {
+			if dependency == nil {
+				return nil, fmt.Errorf("%s: dependencies: index %d: dependency must be set", node.Coordinate, i)
+			}
+			declaration := Declaration{Declarer: node.Coordinate, Dependency: dependency}
+			g.declarations = append(g.declarations, declaration)
+
+			edge := declaration.Edge()
+			if !g.Has(edge.From) || !g.Has(edge.To) {
+				continue
+			}
+			if _, exists := g.edges[edge]; exists {
+				continue
+			}
+			g.edges[edge] = struct{}{}
+			g.upstream[edge.From] = append(g.upstream[edge.From], edge.To)
+			g.downstream[edge.To] = append(g.downstream[edge.To], edge.From)
+		}
+	}
+
+	for _, coordinates := range g.upstream {
+		sortCoordinates(coordinates)
+	}
+	for _, coordinates := range g.downstream {
+		sortCoordinates(coordinates)
+	}
+
+	return g, nil
+}
+
+// Has reports whether the module identified by the coordinate is part of the graph.
+func (g *Graph) Has(coordinate v1.Coordinate) bool {
+	_, ok := g.nodes[coordinate]
+	return ok
+}
+
+// Node returns the node of the module identified by the coordinate.
+func (g *Graph) Node(coordinate v1.Coordinate) (*Node, bool) {
+	node, ok := g.nodes[coordinate]
+	return node, ok
+}
+
+// Nodes returns all nodes ordered by coordinate.
+func (g *Graph) Nodes() []*Node {
+	nodes := make([]*Node, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	sortNodes(nodes)
+	return nodes
+}
+
+// Edges returns all edges between modules of the graph ordered by From and To.
+// An edge declared by both modules is contained once.
+func (g *Graph) Edges() []Edge {
+	edges := make([]Edge, 0, len(g.edges))
+	for edge := range g.edges {
+		edges = append(edges, edge)
+	}
+	sortEdges(edges)
+	return edges
+}
+
+// Declarations returns all dependencies in the order they are declared, ordered by declaring module.
+func (g *Graph) Declarations() []Declaration {
+	return append([]Declaration(nil), g.declarations...)
+}
+
+// Upstream returns the nodes the module identified by the coordinate directly depends on.
+func (g *Graph) Upstream(coordinate v1.Coordinate) []*Node {
+	return g.lookup(g.upstream[coordinate])
+}
+
+// Downstream returns the nodes directly depending on the module identified by the coordinate.
+func (g *Graph) Downstream(coordinate v1.Coordinate) []*Node {
+	return g.lookup(g.downstream[coordinate])
+}
+
+// Dangling returns all declarations referencing a module which is not part of the graph.
+func (g *Graph) Dangling() []Declaration {
+	var dangling []Declaration
+	for _, declaration := range g.declarations {
+		if !g.Has(declaration.Dependency.Coordinate()) {
+			dangling = append(dangling, declaration)
+		}
+	}
+	return dangling
+}
+
+func (g *Graph) lookup(coordinates []v1.Coordinate) []*Node {
+	nodes := make([]*Node, 0, len(coordinates))
+	for _, coordinate := range coordinates {
+		nodes = append(nodes, g.nodes[coordinate])
+	}
+	return nodes
+}
+
+func lessCoordinate(a v1.Coordinate, b v1.Coordinate) bool {
+	return a.String() < b.String()
+}
+
+func sortNodes(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return lessCoordinate(nodes[i].Coordinate, nodes[j].Coordinate)
+	})
+}
+
+func sortCoordinates(coordinates []v1.Coordinate) {
+	sort.Slice(coordinates, func(i, j int) bool {
+		return lessCoordinate(coordinates[i], coordinates[j])
+	})
+}
+
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return lessCoordinate(edges[i].From, edges[j].From)
+		}
+		return lessCoordinate(edges[i].To, edges[j].To)
+	})
+}
@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func coordinate(t *testing.T, s string) v1.Coordinate {
+	t.Helper()
+	c, err := v1.ParseCoordinate(s)
+	if err != nil {
+		t.Fatalf("ParseCoordinate() error = %v", err)
+	}
+	return c
+}
+
+func module(t *testing.T, s string, dependencies ...*v1.ModuleDependency) *v1.Module {
+	t.Helper()
+	m := coordinate(t, s).Module()
+	m.Dependencies = dependencies
+	return m
+}
+
+func upstream(t *testing.T, s string) *v1.ModuleDependency {
+	t.Helper()
+	return coordinate(t, s).Dependency()
+}
+
+func downstream(t *testing.T, s string) *v1.ModuleDependency {
+	t.Helper()
+	d := coordinate(t, s).Dependency()
+	d.Direction = v1.DependencyDirection_DOWNSTREAM.Enum()
+	return d
+}
+
+func coordinates(nodes []*Node) string {
+	var s []string
+	for _, node := range nodes {
+		s = append(s, node.Coordinate.String())
+	}
+	return strings.Join(s, " ")
+}
+
+func edges(edges []Edge) string {
+	var s []string
+	for _, edge := range edges {
+		s = append(s, edge.From.String()+" -> "+edge.To.String())
+	}
+	return strings.Join(s, ", ")
+}
+
+func TestNew(t *testing.T) {
+	g, err := New([]*v1.Module{
+		module(t, "com.example/app:go@v1.0.0",
+			upstream(t, "com.example/lib:go@v1.0.0"),
+			upstream(t, "org.other/missing:go@v1.0.0"),
+		),
+		module(t, "com.example/lib:go@v1.0.0",
+			downstream(t, "com.example/app:go@v1.0.0"),
+			downstream(t, "com.example/tool:go@v2.0.0"),
+		),
+		module(t, "com.example/tool:go@v2.0.0"),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if got, want := coordinates(g.Nodes()), "com.example/app:go@v1.0.0 com.example/lib:go@v1.0.0 com.example/tool:go@v2.0.0"; got != want {
+		t.Errorf("Nodes() = %v, want %v", got, want)
+	}
+	if got, want := edges(g.Edges()), "com.example/app:go@v1.0.0 -> com.example/lib:go@v1.0.0, com.example/tool:go@v2.0.0 -> com.example/lib:go@v1.0.0"; got != want {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+	if got, want := len(g.Declarations()), 4; got != want {
+		t.Errorf("Declarations() = %d, want %d", got, want)
+	}
+
+	lib := coordinate(t, "com.example/lib:go@v1.0.0")
+	if got, want := coordinates(g.Downstream(lib)), "com.example/app:go@v1.0.0 com.example/tool:go@v2.0.0"; got != want {
+		t.Errorf("Downstream() = %v, want %v", got, want)
+	}
+	if got, want := coordinates(g.Upstream(coordinate(t, "com.example/app:go@v1.0.0"))), "com.example/lib:go@v1.0.0"; got != want {
+		t.Errorf("Upstream() = %v, want %v", got, want)
+	}
+	if got := g.Upstream(lib); len(got) != 0 {
+		t.Errorf("Upstream() = %v, want none", coordinates(got))
+	}
+
+	dangling := g.Dangling()
+	if len(dangling) != 1 || dangling[0].Dependency.Coordinate().String() != "org.other/missing:go@v1.0.0" || dangling[0].Declarer.String() != "com.example/app:go@v1.0.0" {
+		t.Errorf("Dangling() = %v", dangling)
+	}
+
+	if node, ok := g.Node(lib); !ok || node.Module.GetName() != "lib" {
+		t.Errorf("Node() = %v, %v", node, ok)
+	}
+	if g.Has(coordinate(t, "org.other/missing:go@v1.0.0")) {
+		t.Errorf("Has() = true, want false")
+	}
+}
+
+func TestNew_resolvesVersion(t *testing.T) {
+	g, err := New([]*v1.Module{
+		module(t, "com.example/app:go@v1.0.0", upstream(t, "com.example/lib:go@v2.0.0")),
+		module(t, "com.example/lib:go@v1.0.0"),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if got := g.Edges(); len(got) != 0 {
+		t.Errorf("Edges() = %v, want none", edges(got))
+	}
+	if got := g.Dangling(); len(got) != 1 {
+		t.Errorf("Dangling() = %v, want one", got)
+	}
+}
+
+func TestNew_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []*v1.Module
+	}{
+		{"has nil module", []*v1.Module{nil}},
+		{"has duplicate module", []*v1.Module{module(t, "com.example/app:go@v1.0.0"), module(t, "com.example/app:go@v1.0.0")}},
+		{"has nil dependency", []*v1.Module{module(t, "com.example/app:go@v1.0.0", nil)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.modules); err == nil {
+				t.Errorf("New() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestDeclaration_Edge(t *testing.T) {
+	declarer := coordinate(t, "com.example/app:go@v1.0.0")
+	target := coordinate(t, "com.example/lib:go@v1.0.0")
+
+	tests := []struct {
+		name       string
+		dependency *v1.ModuleDependency
+		want       Edge
+	}{
+		{"is upstream by default", upstream(t, target.String()), Edge{From: declarer, To: target}},
+		{"is downstream", downstream(t, target.String()), Edge{From: target, To: declarer}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Declaration{Declarer: declarer, Dependency: tt.dependency}.Edge()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Edge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"sort"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Reconciliation is the result of reconciling the UPSTREAM and DOWNSTREAM declarations of a graph.
+type Reconciliation struct {
+	// Edges contains the consistent edges ordered by From and To.
+	// Edges of contradicting declarations are not contained.
+	Edges []Edge
+	// OneSided contains the declarations which are not declared by the other module as well,
+	// e.g. an UPSTREAM dependency without a matching DOWNSTREAM dependency.
+	OneSided []Declaration
+	// Contradictions contains the pairs of modules with contradicting declarations.
+	Contradictions []Contradiction
+}
+
+// Contradiction describes two modules whose declarations imply edges in both directions,
+// e.g. if both modules declare each other as DOWNSTREAM and therefore claim to be upstream of each other.
+type Contradiction struct {
+	// A is the module with the lower coordinate.
+	A v1.Coordinate
+	// B is the module with the higher coordinate.
+	B v1.Coordinate
+	// Declarations contains the contradicting declarations of both modules.
+	Declarations []Declaration
+}
+
+// Reconcile normalizes the declarations between modules of the graph into one edge set.
+// Declarations referencing modules which are not part of the graph are ignored, see Dangling.
+func (g *Graph) Reconcile() Reconciliation {
+	type pair struct {
+		a v1.Coordinate
+		b v1.Coordinate
+	}
+
+	var pairs []pair
+	declarations := make(map[pair][]Declaration)
+	for _, declaration := range g.declarations {
+		edge := declaration.Edge()
+		if !g.Has(edge.From) || !g.Has(edge.To) {
+			continue
+		}
+
+		p := pair{a: edge.From, b: edge.To}
+		if lessCoordinate(p.b, p.a) {
+			p = pair{a: edge.To, b: edge.From}
+		}
+		if _, exists := declarations[p]; !exists {
+			pairs = append(pairs, p)
+		}
+		declarations[p] = append(declarations[p], declaration)
+	}
+
+	var r Reconciliation
+	for _, p := range pairs {
+		pairDeclarations := declarations[p]
+
+		edges := make(map[Edge]struct{})
+		declarers := make(map[v1.Coordinate]struct{})
+		for _, declaration := range pairDeclarations {
+			edges[declaration.Edge()] = struct{}{}
+			declarers[declaration.Declarer] = struct{}{}
+		}
+
+		if len(edges) > 1 {
+			r.Contradictions = append(r.Contradictions, Contradiction{A: p.a, B: p.b, Declarations: pairDeclarations})
+			continue
+		}
+
+		for edge := range edges {
+			r.Edges = append(r.Edges, edge)
+		}
+		// a self-dependency has no other side
+		if len(declarers) == 1 && p.a != p.b {
+			r.OneSided = append(r.OneSided, pairDeclarations...)
+		}
+	}
+
+	sortEdges(r.Edges)
+	sort.SliceStable(r.OneSided, func(i, j int) bool {
+		return lessCoordinate(r.OneSided[i].Declarer, r.OneSided[j].Declarer)
+	})
+	sort.Slice(r.Contradictions, func(i, j int) bool {
+		if r.Contradictions[i].A != r.Contradictions[j].A {
+			return lessCoordinate(r.Contradictions[i].A, r.Contradictions[j].A)
+		}
+		return lessCoordinate(r.Contradictions[i].B, r.Contradictions[j].B)
+	})
+
+	return r
+}
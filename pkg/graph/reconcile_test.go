@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func TestGraph_Reconcile(t *testing.T) {
+	g, err := New([]*v1.Module{
+		// declared on both sides
+		module(t, "com.example/app:go@v1.0.0", upstream(t, "com.example/lib:go@v1.0.0")),
+		module(t, "com.example/lib:go@v1.0.0", downstream(t, "com.example/app:go@v1.0.0")),
+		// declared on one side only
+		module(t, "com.example/cli:go@v1.0.0", upstream(t, "com.example/lib:go@v1.0.0")),
+		module(t, "com.example/log:go@v1.0.0", downstream(t, "com.example/cli:go@v1.0.0")),
+		// both claim to be upstream of each other
+		module(t, "com.example/a:go@v1.0.0", downstream(t, "com.example/b:go@v1.0.0")),
+		module(t, "com.example/b:go@v1.0.0", downstream(t, "com.example/a:go@v1.0.0"), upstream(t, "org.other/missing:go@v1.0.0")),
+		// self-dependency
+		module(t, "com.example/self:go@v1.0.0", upstream(t, "com.example/self:go@v1.0.0")),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	r := g.Reconcile()
+
+	wantEdges := "com.example/app:go@v1.0.0 -> com.example/lib:go@v1.0.0, " +
+		"com.example/cli:go@v1.0.0 -> com.example/lib:go@v1.0.0, " +
+		"com.example/cli:go@v1.0.0 -> com.example/log:go@v1.0.0, " +
+		"com.example/self:go@v1.0.0 -> com.example/self:go@v1.0.0"
+	if got := edges(r.Edges); got != wantEdges {
+		t.Errorf("Edges = %v, want %v", got, wantEdges)
+	}
+
+	if len(r.OneSided) != 2 ||
+		r.OneSided[0].Declarer.String() != "com.example/cli:go@v1.0.0" ||
+		r.OneSided[1].Declarer.String() != "com.example/log:go@v1.0.0" {
+		t.Errorf("OneSided = %v", r.OneSided)
+	}
+
+	if len(r.Contradictions) != 1 {
+		t.Fatalf("Contradictions = %v, want one", r.Contradictions)
+	}
+	c := r.Contradictions[0]
+	if c.A.String() != "com.example/a:go@v1.0.0" || c.B.String() != "com.example/b:go@v1.0.0" || len(c.Declarations) != 2 {
+		t.Errorf("Contradictions[0] = %v", c)
+	}
+}
+
+func TestGraph_Reconcile_mutualUpstream(t *testing.T) {
+	g, err := New([]*v1.Module{
+		module(t, "com.example/a:go@v1.0.0", upstream(t, "com.example/b:go@v1.0.0")),
+		module(t, "com.example/b:go@v1.0.0", upstream(t, "com.example/a:go@v1.0.0")),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	r := g.Reconcile()
+	if len(r.Edges) != 0 || len(r.OneSided) != 0 || len(r.Contradictions) != 1 {
+		t.Errorf("Reconcile() = %+v, want a single contradiction", r)
+	}
+}
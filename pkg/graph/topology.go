@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Cycle is a dependency cycle, whereas each module depends on its successor and the last module depends on the first one.
+type Cycle []v1.Coordinate
+
+// String returns the cycle path like a -> b -> a.
+func (c Cycle) String() string {
+	if len(c) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(c)+1)
+	for _, coordinate := range c {
+		parts = append(parts, coordinate.String())
+	}
+	parts = append(parts, c[0].String())
+
+	return strings.Join(parts, " -> ")
+}
+
+// CycleError reports that a graph cannot be ordered because it contains cycles.
+type CycleError struct {
+	// Cycles contains the detected cycles.
+	Cycles []Cycle
+}
+
+// Error returns all cycle paths.
+func (e *CycleError) Error() string {
+	paths := make([]string, len(e.Cycles))
+	for i, cycle := range e.Cycles {
+		paths[i] = cycle.String()
+	}
+	return fmt.Sprintf("dependency cycles: %s", strings.Join(paths, "; "))
+}
+
+// Cycles returns one cycle for each group of modules depending on each other, i.e. each strongly connected component.
+// A cycle starts at the lowest coordinate of its group and is the shortest path back to it.
+// The cycles are ordered by their first coordinate.
+func (g *Graph) Cycles() []Cycle {
+	var cycles []Cycle
+	for _, component := range g.stronglyConnectedComponents() {
+		start := component[0]
+		for _, coordinate := range component[1:] {
+			if lessCoordinate(coordinate, start) {
+				start = coordinate
+			}
+		}
+
+		if len(component) == 1 && !g.dependsOn(start, start) {
+			continue
+		}
+
+		members := make(map[v1.Coordinate]bool, len(component))
+		for _, coordinate := range component {
+			members[coordinate] = true
+		}
+		cycles = append(cycles, g.shortestCycle(start, members))
+	}
+
+	sortCycles(cycles)
+	return cycles
+}
+
+// TopologicalSort returns all nodes ordered, so that each module follows the modules it depends on.
+// The order is deterministic: it is the concatenation of the Waves.
+// A *CycleError is returned if the graph contains cycles.
+func (g *Graph) TopologicalSort() ([]*Node, error) {
+	waves, err := g.Waves()
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*Node
+	for _, wave := range waves {
+		nodes = append(nodes, wave...)
+	}
+	return nodes, nil
+}
+
+// Waves returns all nodes in layers which can be processed in parallel.
+// The first wave contains the modules without dependencies, each further wave the modules
+// whose dependencies are contained in previous waves. Each wave is ordered by coordinate.
+// A *CycleError is returned if the graph contains cycles.
+func (g *Graph) Waves() ([][]*Node, error) {
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		return nil, &CycleError{Cycles: cycles}
+	}
+
+	pending := make(map[v1.Coordinate]int, len(g.nodes))
+	var wave []*Node
+	for _, node := range g.Nodes() {
+		pending[node.Coordinate] = len(g.upstream[node.Coordinate])
+		if pending[node.Coordinate] == 0 {
+			wave = append(wave, node)
+		}
+	}
+
+	var waves [][]*Node
+	for len(wave) > 0 {
+		waves = append(waves, wave)
+
+		var next []*Node
+		for _, node := range wave {
+			for _, dependent := range g.downstream[node.Coordinate] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					next = append(next, g.nodes[dependent])
+				}
+			}
+		}
+		sortNodes(next)
+		wave = next
+	}
+
+	return waves, nil
+}
+
+// stronglyConnectedComponents implements Tarjan's algorithm over the upstream edges.
+func (g *Graph) stronglyConnectedComponents() [][]v1.Coordinate {
+	index := 0
+	indices := make(map[v1.Coordinate]int, len(g.nodes))
+	lowLinks := make(map[v1.Coordinate]int, len(g.nodes))
+	onStack := make(map[v1.Coordinate]bool, len(g.nodes))
+	var stack []v1.Coordinate
+	var components [][]v1.Coordinate
+
+	var connect func(v1.Coordinate)
+	connect = func(coordinate v1.Coordinate) {
+		indices[coordinate] = index
+		lowLinks[coordinate] = index
+		index++
+		stack = append(stack, coordinate)
+		onStack[coordinate] = true
+
+		for _, next := range g.upstream[coordinate] {
+			if _, visited := indices[next]; !visited {
+				connect(next)
+				if lowLinks[next] < lowLinks[coordinate] {
+					lowLinks[coordinate] = lowLinks[next]
+				}
+			} else if onStack[next] && indices[next] < lowLinks[coordinate] {
+				lowLinks[coordinate] = indices[next]
+			}
+		}
+
+		if lowLinks[coordinate] == indices[coordinate] {
+			var component []v1.Coordinate
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == coordinate {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+
+	for _, node := range g.Nodes() {
+		if _, visited := indices[node.Coordinate]; !visited {
+			connect(node.Coordinate)
+		}
+	}
+
+	return components
+}
+
+// shortestCycle returns the shortest path from start back to start within the members using breadth-first search.
+func (g *Graph) shortestCycle(start v1.Coordinate, members map[v1.Coordinate]bool) Cycle {
+	previous := make(map[v1.Coordinate]v1.Coordinate)
+	queue := []v1.Coordinate{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range g.upstream[current] {
+			if next == start {
+				cycle := Cycle{current}
+				for current != start {
+					current = previous[current]
+					cycle = append(cycle, current)
+				}
+				for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				return cycle
+			}
+			if _, visited := previous[next]; visited || !members[next] {
+				continue
+			}
+			previous[next] = current
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+func (g *Graph) dependsOn(from v1.Coordinate, to v1.Coordinate) bool {
+	_, ok := g.edges[Edge{From: from, To: to}]
+	return ok
+}
+
+func sortCycles(cycles []Cycle) {
+	sort.Slice(cycles, func(i, j int) bool {
+		return lessCoordinate(cycles[i][0], cycles[j][0])
+	})
+}
@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func waves(waves [][]*Node) string {
+	var s []string
+	for _, wave := range waves {
+		s = append(s, coordinates(wave))
+	}
+	return strings.Join(s, " | ")
+}
+
+func TestGraph_Waves(t *testing.T) {
+	g, err := New([]*v1.Module{
+		module(t, "com.example/app:go@v1.0.0",
+			upstream(t, "com.example/lib:go@v1.0.0"),
+			upstream(t, "com.example/log:go@v1.0.0"),
+		),
+		module(t, "com.example/lib:go@v1.0.0", upstream(t, "com.example/log:go@v1.0.0")),
+		module(t, "com.example/log:go@v1.0.0"),
+		// declared from the upstream side
+		module(t, "com.example/base:go@v1.0.0", downstream(t, "com.example/log:go@v1.0.0")),
+		module(t, "com.example/tool:go@v1.0.0", upstream(t, "com.example/base:go@v1.0.0")),
+		// another version is a different module
+		module(t, "com.example/log:go@v2.0.0", upstream(t, "com.example/app:go@v1.0.0")),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got, err := g.Waves()
+	if err != nil {
+		t.Fatalf("Waves() error = %v", err)
+	}
+	want := "com.example/base:go@v1.0.0" +
+		" | com.example/log:go@v1.0.0 com.example/tool:go@v1.0.0" +
+		" | com.example/lib:go@v1.0.0" +
+		" | com.example/app:go@v1.0.0" +
+		" | com.example/log:go@v2.0.0"
+	if waves(got) != want {
+		t.Errorf("Waves() = %v, want %v", waves(got), want)
+	}
+
+	sorted, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort() error = %v", err)
+	}
+	if got, want := coordinates(sorted), strings.ReplaceAll(want, " | ", " "); got != want {
+		t.Errorf("TopologicalSort() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_Cycles(t *testing.T) {
+	g, err := New([]*v1.Module{
+		module(t, "com.example/a:go@v1.0.0", upstream(t, "org.other/b:go@v1.0.0")),
+		module(t, "org.other/b:go@v1.0.0", upstream(t, "net.third/c:go@v1.0.0")),
+		module(t, "net.third/c:go@v1.0.0", upstream(t, "com.example/a:go@v1.0.0"), upstream(t, "org.other/b:go@v1.0.0")),
+		module(t, "com.example/self:go@v1.0.0", upstream(t, "com.example/self:go@v1.0.0")),
+		module(t, "com.example/ok:go@v1.0.0", upstream(t, "com.example/a:go@v1.0.0")),
+		// a downstream declaration closing a cycle
+		module(t, "com.example/x:go@v1.0.0", upstream(t, "com.example/y:go@v1.0.0")),
+		module(t, "com.example/y:go@v1.0.0", downstream(t, "com.example/x:go@v1.0.0"), downstream(t, "com.example/x:go@v1.0.0")),
+		module(t, "com.example/z:go@v1.0.0", downstream(t, "com.example/y:go@v1.0.0")),
+		module(t, "com.example/w:go@v1.0.0", upstream(t, "com.example/z:go@v1.0.0"), downstream(t, "com.example/z:go@v1.0.0")),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var got []string
+	for _, cycle := range g.Cycles() {
+		got = append(got, cycle.String())
+	}
+	want := []string{
+		"com.example/a:go@v1.0.0 -> org.other/b:go@v1.0.0 -> net.third/c:go@v1.0.0 -> com.example/a:go@v1.0.0",
+		"com.example/self:go@v1.0.0 -> com.example/self:go@v1.0.0",
+		"com.example/w:go@v1.0.0 -> com.example/z:go@v1.0.0 -> com.example/w:go@v1.0.0",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Cycles() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	_, err = g.Waves()
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) || len(cycleErr.Cycles) != 3 {
+		t.Errorf("Waves() error = %v, want CycleError", err)
+	}
+	if _, err := g.TopologicalSort(); !errors.As(err, &cycleErr) {
+		t.Errorf("TopologicalSort() error = %v, want CycleError", err)
+	}
+}
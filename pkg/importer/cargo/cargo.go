@@ -0,0 +1,305 @@
+// Package cargo imports Rust crates from Cargo.toml manifests and Cargo.lock lockfiles,
+// see https://doc.rust-lang.org/cargo/reference/manifest.html.
+//
+// Crates have no namespace and are mapped onto the namespace DefaultNamespace. Because dependencies
+// cannot carry annotations, the original names and dependency details are kept as module annotations:
+//
+//	cargo.name                the crate name
+//	cargo.dependency.<i>      the crate name and version requirement of the i-th dependency
+//	cargo.group.<i>           the comma-separated dependency groups of the i-th dependency: normal, dev or build
+//	cargo.rename.<i>          the name the i-th dependency is renamed to by the manifest
+//	cargo.optional.<i>        "true" if the i-th dependency is optional
+//	cargo.target.<i>          the comma-separated platform specifications of target-specific dependencies
+package cargo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+	"github.com/opendependency/go-spec/pkg/importer/internal/toml"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+const (
+	// Type is the module type of Rust crates.
+	Type = "cargo"
+	// DefaultNamespace is the namespace of all crates.
+	DefaultNamespace = "cargo"
+)
+
+// Dependency groups as recorded in the cargo.group.<i> annotations.
+const (
+	GroupNormal = "normal"
+	GroupDev    = "dev"
+	GroupBuild  = "build"
+)
+
+// groupTables maps the manifest tables onto dependency groups in their import order.
+var groupTables = []struct {
+	table string
+	group string
+}{
+	{"dependencies", GroupNormal},
+	{"dev-dependencies", GroupDev},
+	{"build-dependencies", GroupBuild},
+}
+
+// requirement is a dependency as declared by the manifest.
+type requirement struct {
+	// key is the name used by the manifest, which differs from the crate name if the dependency is renamed.
+	key      string
+	crate    string
+	version  string
+	optional bool
+	group    string
+	target   string
+	// source is the path or git repository of dependencies not taken from a registry, e.g. "path ../lib".
+	source string
+}
+
+// Read parses the Cargo.toml manifest and the optional Cargo.lock lockfile and converts them into a module.
+// The lockfile may be nil.
+//
+// All dependencies, including development, build and target-specific ones, become UPSTREAM dependencies,
+// whereas a crate declared several times becomes a single dependency. Dependencies use the version resolved
+// by the lockfile, if any, and the lower bound of the version requirement otherwise, e.g. 1.2.0 for "1.2".
+// Identifiers are normalized to satisfy the specification constraints and all lossy transformations
+// are recorded in the returned report, including the paths and git repositories of dependencies.
+func Read(manifestReader io.Reader, lockfileReader io.Reader) (*v1.Module, *importer.Report, error) {
+	manifest, err := parse(manifestReader, "Cargo.toml")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkg := toml.Table(manifest, "package")
+	name := toml.String(pkg, "name")
+	if name == "" {
+		return nil, nil, errors.New("Cargo.toml: package.name must be set")
+	}
+
+	var resolved map[string][]string
+	if lockfileReader != nil {
+		lockfile, err := parse(lockfileReader, "Cargo.lock")
+		if err != nil {
+			return nil, nil, err
+		}
+		resolved = resolve(lockfile, name)
+	}
+
+	report := &importer.Report{}
+
+	c, ok := report.Coordinate(name, "", v1.Coordinate{Namespace: DefaultNamespace, Name: name, Type: Type, Version: toml.String(pkg, "version")})
+	if !ok {
+		return nil, nil, fmt.Errorf("Cargo.toml: package.name %q: no valid name can be derived", name)
+	}
+	module := c.Module()
+	module.Annotations = map[string]string{"cargo.name": name}
+
+	indexes := make(map[string]int)
+	for _, r := range requirements(manifest) {
+		if r.source != "" {
+			report.Drop(r.crate, "source", r.source, "path and git dependencies cannot be represented")
+		}
+		if i, ok := indexes[r.crate]; ok {
+			appendAnnotation(module, "cargo.group."+strconv.Itoa(i), r.group)
+			appendAnnotation(module, "cargo.target."+strconv.Itoa(i), r.target)
+			continue
+		}
+
+		version := r.version
+		if versions := resolved[r.crate]; len(versions) > 0 {
+			version = versions[0]
+			if len(versions) > 1 {
+				report.Add(r.crate, "version", strings.Join(versions, ","), version, "multiple versions resolved by the lockfile")
+			}
+		} else if exact := strings.TrimPrefix(strings.TrimSpace(r.version), "="); isExactVersion(exact) {
+			version = exact
+		} else if lower, ok := lowerBound(r.version); ok {
+			report.Add(r.crate, "version", r.version, lower, "version requirement cannot be represented, using lower bound")
+			version = lower
+		}
+
+		c, ok := report.Coordinate(r.crate, "", v1.Coordinate{Namespace: DefaultNamespace, Name: r.crate, Type: Type, Version: version})
+		if !ok {
+			report.Drop(r.crate, "dependency", r.crate+" "+r.version, "no valid name can be derived")
+			continue
+		}
+
+		i := strconv.Itoa(len(module.Dependencies))
+		indexes[r.crate] = len(module.Dependencies)
+		module.Dependencies = append(module.Dependencies, c.Dependency())
+		module.Annotations["cargo.dependency."+i] = report.NormalizeAnnotationValue(r.crate, "annotations.cargo.dependency", strings.TrimSpace(r.crate+" "+r.version))
+		module.Annotations["cargo.group."+i] = r.group
+		if r.key != r.crate {
+			module.Annotations["cargo.rename."+i] = r.key
+		}
+		if r.optional {
+			module.Annotations["cargo.optional."+i] = "true"
+		}
+		appendAnnotation(module, "cargo.target."+i, r.target)
+	}
+
+	return module, report, nil
+}
+
+func parse(r io.Reader, file string) (map[string]interface{}, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	document, err := toml.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return document, nil
+}
+
+// requirements returns the dependencies of the manifest, grouped and sorted by name,
+// followed by the target-specific dependencies sorted by target.
+func requirements(manifest map[string]interface{}) []requirement {
+	var result []requirement
+	for _, g := range groupTables {
+		result = append(result, tableRequirements(toml.Table(manifest, g.table), g.group, "")...)
+	}
+
+	targets := toml.Table(manifest, "target")
+	for _, target := range sortedKeys(targets) {
+		for _, g := range groupTables {
+			result = append(result, tableRequirements(toml.Table(targets, target, g.table), g.group, target)...)
+		}
+	}
+
+	return result
+}
+
+func tableRequirements(table map[string]interface{}, group string, target string) []requirement {
+	var result []requirement
+	for _, key := range sortedKeys(table) {
+		r := requirement{key: key, crate: key, group: group, target: target}
+		switch declaration := table[key].(type) {
+		case string:
+			r.version = declaration
+		case map[string]interface{}:
+			r.version = toml.String(declaration, "version")
+			if crate := toml.String(declaration, "package"); crate != "" {
+				r.crate = crate
+			}
+			r.optional, _ = declaration["optional"].(bool)
+			if path := toml.String(declaration, "path"); path != "" {
+				r.source = "path " + path
+			} else if git := toml.String(declaration, "git"); git != "" {
+				r.source = "git " + git
+			}
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// resolve returns the versions of the crates the root package depends on according to the lockfile.
+func resolve(lockfile map[string]interface{}, root string) map[string][]string {
+	versions := make(map[string][]string)
+	var dependencies []string
+	for _, pkg := range toml.Tables(lockfile, "package") {
+		name := toml.String(pkg, "name")
+		versions[name] = append(versions[name], toml.String(pkg, "version"))
+		if name == root {
+			dependencies = toml.Strings(pkg, "dependencies")
+		}
+	}
+
+	// a dependency is listed as "name" if the lockfile contains a single version of the crate
+	// and as "name version" or "name version (source)" otherwise
+	resolved := make(map[string][]string)
+	for _, dependency := range dependencies {
+		fields := strings.Fields(dependency)
+		if len(fields) > 1 {
+			resolved[fields[0]] = append(resolved[fields[0]], fields[1])
+		} else if len(fields) == 1 {
+			resolved[fields[0]] = append(resolved[fields[0]], versions[fields[0]]...)
+		}
+	}
+	return resolved
+}
+
+// isExactVersion matches exact versions like 1.2.3 or 1.2.3-rc.1+build.
+var isExactVersion = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`).MatchString
+
+// requirementVersion matches the version of a comparator, which may be partial like 1.2 or contain wildcards like 1.*.
+var requirementVersion = regexp.MustCompile(`^(\d+)(?:\.(\d+|\*))?(?:\.(\d+|\*))?(-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// lowerBound returns the smallest version of the version requirement, e.g. 1.2.0 for "1.2" or ">=1.2, <1.5".
+// Partial versions and wildcards are completed with zeros and requirements without lower bound like <2 yield 0.0.0.
+// It returns false if the value is no version requirement.
+func lowerBound(requirement string) (string, bool) {
+	if strings.TrimSpace(requirement) == "" {
+		return "", false
+	}
+
+	lower, _ := v1.ParseVersion("0.0.0", v1.VersionSchemaSemVer)
+	for _, comparator := range strings.Split(requirement, ",") {
+		comparator = strings.TrimSpace(comparator)
+		operator := ""
+		for _, op := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+			if strings.HasPrefix(comparator, op) {
+				operator, comparator = op, strings.TrimSpace(comparator[len(op):])
+				break
+			}
+		}
+		if comparator == "*" || (comparator == "" && operator == "") {
+			continue
+		}
+
+		match := requirementVersion.FindStringSubmatch(comparator)
+		if match == nil {
+			return "", false
+		}
+		version, err := v1.ParseVersion(match[1]+"."+zeroWildcard(match[2])+"."+zeroWildcard(match[3])+match[4], v1.VersionSchemaSemVer)
+		if err != nil {
+			return "", false
+		}
+		if operator != "<" && operator != "<=" && version.Compare(lower) > 0 {
+			lower = version
+		}
+	}
+	return lower.String(), true
+}
+
+func zeroWildcard(component string) string {
+	if component == "" || component == "*" {
+		return "0"
+	}
+	return component
+}
+
+// appendAnnotation appends the value to the comma-separated annotation unless it is empty or already contained.
+func appendAnnotation(module *v1.Module, key string, value string) {
+	if value == "" {
+		return
+	}
+	existing := module.Annotations[key]
+	for _, v := range strings.Split(existing, ",") {
+		if v == value {
+			return
+		}
+	}
+	if existing != "" {
+		value = existing + "," + value
+	}
+	module.Annotations[key] = value
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,233 @@
+package cargo
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const cargoToml = `[package]
+name = "my_service"
+version = "0.3.1"
+edition = "2021"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+tokio = "1"
+json = { package = "serde_json", version = "1.0.100", optional = true }
+rand = { version = "0.7" }
+
+[dev-dependencies]
+serde = "1.0"
+pretty_assertions = "^1.4"
+
+[target.'cfg(unix)'.dependencies]
+libc = "0.2"
+
+[target.'cfg(windows)'.dependencies]
+libc = "0.2"
+`
+
+const cargoLock = `version = 3
+
+[[package]]
+name = "my_service"
+version = "0.3.1"
+dependencies = [
+ "libc",
+ "pretty_assertions",
+ "rand 0.7.3",
+ "serde",
+ "serde_json",
+ "tokio",
+]
+
+[[package]]
+name = "libc"
+version = "0.2.147"
+
+[[package]]
+name = "rand"
+version = "0.7.3"
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+
+[[package]]
+name = "serde"
+version = "1.0.188"
+
+[[package]]
+name = "serde_json"
+version = "1.0.107"
+
+[[package]]
+name = "tokio"
+version = "1.32.0"
+`
+
+func TestRead(t *testing.T) {
+	module, report, err := Read(strings.NewReader(cargoToml), strings.NewReader(cargoLock))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if got := module.Coordinate().String(); got != "cargo/my-service:cargo@0.3.1" {
+		t.Errorf("Read() = %v, want cargo/my-service:cargo@0.3.1", got)
+	}
+	if err := module.ValidateAll(); err != nil {
+		t.Errorf("Read() module is invalid: %v", err)
+	}
+
+	var dependencies []string
+	for _, d := range module.Dependencies {
+		dependencies = append(dependencies, d.Coordinate().String())
+	}
+	wantDependencies := []string{
+		"cargo/serde-json:cargo@1.0.107",
+		"cargo/rand:cargo@0.7.3",
+		"cargo/serde:cargo@1.0.188",
+		"cargo/tokio:cargo@1.32.0",
+		"cargo/pretty-assertions:cargo@1.4.0",
+		"cargo/libc:cargo@0.2.147",
+	}
+	if !reflect.DeepEqual(dependencies, wantDependencies) {
+		t.Errorf("Read() dependencies = %v, want %v", dependencies, wantDependencies)
+	}
+
+	wantAnnotations := map[string]string{
+		"cargo.name":         "my_service",
+		"cargo.dependency.0": "serde_json 1.0.100",
+		"cargo.group.0":      "normal",
+		"cargo.rename.0":     "json",
+		"cargo.optional.0":   "true",
+		"cargo.dependency.1": "rand 0.7",
+		"cargo.group.1":      "normal",
+		"cargo.dependency.2": "serde 1.0",
+		"cargo.group.2":      "normal,dev",
+		"cargo.dependency.3": "tokio 1",
+		"cargo.group.3":      "normal",
+		"cargo.dependency.4": "pretty_assertions ^1.4",
+		"cargo.group.4":      "dev",
+		"cargo.dependency.5": "libc 0.2",
+		"cargo.group.5":      "normal",
+		"cargo.target.5":     "cfg(unix),cfg(windows)",
+	}
+	if !reflect.DeepEqual(module.Annotations, wantAnnotations) {
+		t.Errorf("Read() annotations = %v, want %v", module.Annotations, wantAnnotations)
+	}
+
+	wantReport := `my_service: name "my_service" changed to "my-service": must be a lowercase alphanumeric identifier
+serde_json: name "serde_json" changed to "serde-json": must be a lowercase alphanumeric identifier
+pretty_assertions: version "^1.4" changed to "1.4.0": version requirement cannot be represented, using lower bound
+pretty_assertions: name "pretty_assertions" changed to "pretty-assertions": must be a lowercase alphanumeric identifier`
+	if got := report.String(); got != wantReport {
+		t.Errorf("Read() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func TestRead_withoutLockfile(t *testing.T) {
+	manifest := `[package]
+name = "app"
+version = "1.0.0"
+
+[dependencies]
+exact = "=1.2.3"
+caret = "1.0"
+range = ">=1.2, <1.5"
+local = { path = "../local" }
+shared = { path = "../shared", version = "0.3" }
+forked = { git = "https://github.com/example/forked", branch = "main" }
+
+[dev-dependencies]
+exact = { path = "../exact" }
+`
+	module, report, err := Read(strings.NewReader(manifest), nil)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	var dependencies []string
+	for _, d := range module.Dependencies {
+		dependencies = append(dependencies, d.Coordinate().String())
+	}
+	wantDependencies := []string{
+		"cargo/caret:cargo@1.0.0",
+		"cargo/exact:cargo@1.2.3",
+		"cargo/forked:cargo@unknown",
+		"cargo/local:cargo@unknown",
+		"cargo/range:cargo@1.2.0",
+		"cargo/shared:cargo@0.3.0",
+	}
+	if !reflect.DeepEqual(dependencies, wantDependencies) {
+		t.Errorf("Read() dependencies = %v, want %v", dependencies, wantDependencies)
+	}
+
+	wantReport := `caret: version "1.0" changed to "1.0.0": version requirement cannot be represented, using lower bound
+forked: source "git https://github.com/example/forked" dropped: path and git dependencies cannot be represented
+forked: version "" changed to "unknown": must be set
+local: source "path ../local" dropped: path and git dependencies cannot be represented
+local: version "" changed to "unknown": must be set
+range: version ">=1.2, <1.5" changed to "1.2.0": version requirement cannot be represented, using lower bound
+shared: source "path ../shared" dropped: path and git dependencies cannot be represented
+shared: version "0.3" changed to "0.3.0": version requirement cannot be represented, using lower bound
+exact: source "path ../exact" dropped: path and git dependencies cannot be represented`
+	if got := report.String(); got != wantReport {
+		t.Errorf("Read() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func Test_lowerBound(t *testing.T) {
+	tests := []struct {
+		requirement string
+		want        string
+		wantOK      bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2", "1.2.0", true},
+		{"^0.3", "0.3.0", true},
+		{"~1", "1.0.0", true},
+		{">= 1.2, < 1.5", "1.2.0", true},
+		{"<2", "0.0.0", true},
+		{"1.*", "1.0.0", true},
+		{"*", "0.0.0", true},
+		{"=1.0.0-rc.1", "1.0.0-rc.1", true},
+		{"", "", false},
+		{"latest", "", false},
+		{"01.2", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.requirement, func(t *testing.T) {
+			got, ok := lowerBound(tt.requirement)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("lowerBound() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRead_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		lockfile string
+	}{
+		{"invalid manifest", "[package", ""},
+		{"missing name", "[package]\nversion = \"1.0.0\"", ""},
+		{"invalid name", "[package]\nname = \"___\"", ""},
+		{"invalid lockfile", "[package]\nname = \"app\"", "[[package"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lockfile io.Reader
+			if tt.lockfile != "" {
+				lockfile = strings.NewReader(tt.lockfile)
+			}
+			if _, _, err := Read(strings.NewReader(tt.manifest), lockfile); err == nil {
+				t.Errorf("Read() error = nil, want error")
+			}
+		})
+	}
+}
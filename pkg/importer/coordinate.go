@@ -0,0 +1,65 @@
+package importer
+
+import (
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+const (
+	// DefaultType is the module type of imported modules without type, following the generic package URL type.
+	DefaultType = "generic"
+	// DefaultVersion is the module version of imported modules without version.
+	DefaultVersion = "unknown"
+)
+
+// Coordinate derives the normalized coordinate of an imported module and records lossy transformations.
+//
+// The coordinate is taken from the package URL, if it is valid, and from the fallback otherwise.
+// Package URL qualifiers and subpaths are dropped. A missing type defaults to DefaultType, a missing namespace
+// to the type and a missing version to DefaultVersion. It returns false if no name can be derived.
+func (r *Report) Coordinate(source string, purl string, fallback v1.Coordinate) (v1.Coordinate, bool) {
+	c := fallback
+	if purl != "" {
+		p, err := v1.ParsePackageURL(purl)
+		if err != nil {
+			r.Drop(source, "purl", purl, err.Error())
+		} else {
+			c = v1.Coordinate{Namespace: p.Namespace, Name: p.Name, Type: p.Type, Version: p.Version}
+			for _, key := range sortedKeys(p.Qualifiers) {
+				r.Drop(source, "purl qualifier", key+"="+p.Qualifiers[key], "qualifiers cannot be represented")
+			}
+			if p.Subpath != "" {
+				r.Drop(source, "purl subpath", p.Subpath, "subpaths cannot be represented")
+			}
+		}
+	}
+
+	if c.Type == "" {
+		r.Add(source, "type", "", DefaultType, "must be set")
+		c.Type = DefaultType
+	}
+	if c.Type = r.NormalizeIdentifier(source, "type", c.Type); c.Type == "" {
+		c.Type = DefaultType
+	}
+
+	if c.Namespace == "" {
+		r.Add(source, "namespace", "", c.Type, "must be set")
+		c.Namespace = c.Type
+	}
+	if c.Namespace = r.NormalizeIdentifier(source, "namespace", c.Namespace); c.Namespace == "" {
+		c.Namespace = c.Type
+	}
+
+	if c.Version == "" {
+		r.Add(source, "version", "", DefaultVersion, "must be set")
+		c.Version = DefaultVersion
+	}
+	if c.Version = r.NormalizeVersion(source, "version", c.Version); c.Version == "" {
+		c.Version = DefaultVersion
+	}
+
+	if c.Name = r.NormalizeIdentifier(source, "name", c.Name); c.Name == "" {
+		return v1.Coordinate{}, false
+	}
+
+	return c, true
+}
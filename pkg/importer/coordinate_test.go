@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func TestReport_Coordinate(t *testing.T) {
+	tests := []struct {
+		name       string
+		purl       string
+		fallback   v1.Coordinate
+		want       string
+		wantOK     bool
+		wantLosses int
+	}{
+		{"package URL", "pkg:npm/acme/app@1.0.0", v1.Coordinate{Name: "ignored"}, "acme/app:npm@1.0.0", true, 0},
+		{"package URL with qualifiers and subpath", "pkg:npm/acme/app@1.0.0?arch=x64&os=linux#lib", v1.Coordinate{}, "acme/app:npm@1.0.0", true, 3},
+		{"package URL without namespace", "pkg:npm/app@1.0.0", v1.Coordinate{}, "npm/app:npm@1.0.0", true, 1},
+		{"invalid package URL", "npm/app", v1.Coordinate{Namespace: "acme", Name: "app", Type: "npm", Version: "1.0.0"}, "acme/app:npm@1.0.0", true, 1},
+		{"fallback", "", v1.Coordinate{Namespace: "acme", Name: "app", Type: "npm", Version: "1.0.0"}, "acme/app:npm@1.0.0", true, 0},
+		{"fallback without type and version", "", v1.Coordinate{Namespace: "acme", Name: "app"}, "acme/app:generic@unknown", true, 2},
+		{"normalized fallback", "", v1.Coordinate{Namespace: "Acme", Name: "App", Type: "npm", Version: "1.0.0+1"}, "acme/app:npm@1.0.0-1", true, 3},
+		{"no name", "", v1.Coordinate{Namespace: "acme", Name: "@@", Type: "npm", Version: "1.0.0"}, "", false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r Report
+			got, ok := r.Coordinate("source", tt.purl, tt.fallback)
+			if ok != tt.wantOK {
+				t.Fatalf("Coordinate() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("Coordinate() = %v, want %v", got, tt.want)
+			}
+			if len(r.Losses) != tt.wantLosses {
+				t.Errorf("Coordinate() losses =\n%s\nwant %d", &r, tt.wantLosses)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Package gomod imports Go modules from go.mod files, see https://go.dev/ref/mod#go-mod-file.
+//
+// A module path is mapped onto namespace and name by splitting it at its last path element,
+// whereas a major version suffix is appended to the name and slashes in the namespace become dots,
+// e.g. github.com/spf13/cobra becomes github.com.spf13/cobra and gopkg.in/yaml.v3 becomes gopkg.in/yaml.v3.
+// Because this mapping is not reversible, the original paths are kept as annotations:
+//
+//	go.path                   the module path
+//	go.language-version       the version of the go directive
+//	go.toolchain              the toolchain directive
+//	go.require.<i>            the module path and version required by the i-th dependency
+//	go.indirect.<i>           "true" if the i-th dependency is marked as // indirect
+//	go.replace.<n>            the n-th replace directive, e.g. "golang.org/x/net v1.2.3 => ./fork/net"
+//	go.exclude.<n>            the n-th exclude directive, e.g. "golang.org/x/net v1.2.3"
+//	go.retract.<n>            the n-th retract directive, e.g. "[v1.0.0, v1.0.5]"
+package gomod
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Type is the module type of Go modules.
+const Type = "go"
+
+var isMajorVersion = regexp.MustCompile(`^v[0-9]+$`).MatchString
+
+// Read parses the go.mod file and converts it into a module of the given version.
+// If the version is empty, it defaults to importer.DefaultVersion. Required modules become UPSTREAM dependencies.
+// Identifiers are normalized to satisfy the specification constraints and all lossy transformations
+// are recorded in the returned report, including unknown directives.
+func Read(r io.Reader, version string) (*v1.Module, *importer.Report, error) {
+	directives, err := parse(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &importer.Report{}
+	annotations := make(map[string]string)
+	var dependencies []*v1.ModuleDependency
+	var path string
+	counts := make(map[string]int)
+
+	for _, d := range directives {
+		switch d.verb {
+		case "module":
+			if len(d.args) != 1 {
+				return nil, nil, fmt.Errorf("go.mod:%d: module directive must have the form: module path", d.line)
+			}
+			path = d.args[0]
+		case "go", "toolchain":
+			if len(d.args) != 1 {
+				return nil, nil, fmt.Errorf("go.mod:%d: %s directive must have the form: %s version", d.line, d.verb, d.verb)
+			}
+			if d.verb == "go" {
+				annotations["go.language-version"] = d.args[0]
+			} else {
+				annotations["go.toolchain"] = d.args[0]
+			}
+		case "require":
+			if len(d.args) != 2 {
+				return nil, nil, fmt.Errorf("go.mod:%d: require directive must have the form: require path version", d.line)
+			}
+			coordinate, ok := report.Coordinate(d.args[0], "", coordinate(d.args[0], d.args[1]))
+			if !ok {
+				report.Drop(d.args[0], "dependency", d.args[0]+" "+d.args[1], "no valid name can be derived")
+				continue
+			}
+			i := strconv.Itoa(len(dependencies))
+			dependencies = append(dependencies, coordinate.Dependency())
+			annotations["go.require."+i] = d.args[0] + " " + d.args[1]
+			if isIndirect(d.comment) {
+				annotations["go.indirect."+i] = "true"
+			}
+		case "replace", "exclude", "retract":
+			if err := validateArgs(d); err != nil {
+				return nil, nil, err
+			}
+			annotations[fmt.Sprintf("go.%s.%d", d.verb, counts[d.verb])] = strings.Join(d.args, " ")
+			counts[d.verb]++
+		default:
+			report.Drop(fmt.Sprintf("go.mod:%d", d.line), "directive", d.verb+" "+strings.Join(d.args, " "), "unknown directive")
+		}
+	}
+
+	if path == "" {
+		return nil, nil, errors.New("go.mod: module directive must be set")
+	}
+	annotations["go.path"] = path
+
+	c, ok := report.Coordinate(path, "", coordinate(path, version))
+	if !ok {
+		return nil, nil, fmt.Errorf("go.mod: module path %q: no valid name can be derived", path)
+	}
+
+	module := c.Module()
+	module.Dependencies = dependencies
+	for k, v := range annotations {
+		annotations[k] = report.NormalizeAnnotationValue(path, "annotations."+k, v)
+	}
+	module.Annotations = annotations
+
+	return module, report, nil
+}
+
+// coordinate maps the module path onto namespace and name.
+func coordinate(path string, version string) v1.Coordinate {
+	elements := strings.Split(strings.Trim(path, "/"), "/")
+	name := elements[len(elements)-1]
+	elements = elements[:len(elements)-1]
+	if len(elements) > 0 && isMajorVersion(name) {
+		name = elements[len(elements)-1] + "." + name
+		elements = elements[:len(elements)-1]
+	}
+
+	return v1.Coordinate{
+		Namespace: strings.Join(elements, "."),
+		Name:      name,
+		Type:      Type,
+		Version:   version,
+	}
+}
+
+func isIndirect(comment string) bool {
+	return comment == "indirect" || strings.HasPrefix(comment, "indirect;")
+}
+
+func validateArgs(d directive) error {
+	switch d.verb {
+	case "replace":
+		arrow := -1
+		for i, arg := range d.args {
+			if arg == "=>" {
+				arrow = i
+			}
+		}
+		if arrow < 1 || arrow > 2 || len(d.args)-arrow-1 < 1 || len(d.args)-arrow-1 > 2 {
+			return fmt.Errorf("go.mod:%d: replace directive must have the form: replace path [version] => path [version]", d.line)
+		}
+	case "exclude":
+		if len(d.args) != 2 {
+			return fmt.Errorf("go.mod:%d: exclude directive must have the form: exclude path version", d.line)
+		}
+	case "retract":
+		if len(d.args) == 0 {
+			return fmt.Errorf("go.mod:%d: retract directive must have the form: retract version or retract [low, high]", d.line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package gomod
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const goMod = `// Module app is an example.
+module github.com/Acme/app/v2
+
+go 1.17
+
+toolchain go1.21.0
+
+require github.com/spf13/cobra v1.2.1
+
+require (
+	gopkg.in/yaml.v3 v3.0.1
+	github.com/pkg/errors v0.9.1+incompatible // indirect
+	"golang.org/x/sys" v0.1.0 // indirect; used by tests
+)
+
+replace golang.org/x/sys v0.1.0 => ./fork/sys
+
+replace (
+	github.com/pkg/errors => github.com/acme/errors v0.9.2
+)
+
+exclude github.com/spf13/cobra v1.2.0
+
+retract [v2.0.0, v2.0.1] // published accidentally
+
+godebug default=go1.21
+`
+
+func TestRead(t *testing.T) {
+	module, report, err := Read(strings.NewReader(goMod), "v2.1.0")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if got := module.Coordinate().String(); got != "github.com.acme/app.v2:go@v2.1.0" {
+		t.Errorf("Read() = %v, want github.com.acme/app.v2:go@v2.1.0", got)
+	}
+	if err := module.ValidateAll(); err != nil {
+		t.Errorf("Read() module is invalid: %v", err)
+	}
+
+	var dependencies []string
+	for _, d := range module.Dependencies {
+		dependencies = append(dependencies, d.Coordinate().String())
+	}
+	wantDependencies := []string{
+		"github.com.spf13/cobra:go@v1.2.1",
+		"gopkg.in/yaml.v3:go@v3.0.1",
+		"github.com.pkg/errors:go@v0.9.1-incompatible",
+		"golang.org.x/sys:go@v0.1.0",
+	}
+	if !reflect.DeepEqual(dependencies, wantDependencies) {
+		t.Errorf("Read() dependencies = %v, want %v", dependencies, wantDependencies)
+	}
+
+	wantAnnotations := map[string]string{
+		"go.path":             "github.com/Acme/app/v2",
+		"go.language-version": "1.17",
+		"go.toolchain":        "go1.21.0",
+		"go.require.0":        "github.com/spf13/cobra v1.2.1",
+		"go.require.1":        "gopkg.in/yaml.v3 v3.0.1",
+		"go.require.2":        "github.com/pkg/errors v0.9.1+incompatible",
+		"go.indirect.2":       "true",
+		"go.require.3":        "golang.org/x/sys v0.1.0",
+		"go.indirect.3":       "true",
+		"go.replace.0":        "golang.org/x/sys v0.1.0 => ./fork/sys",
+		"go.replace.1":        "github.com/pkg/errors => github.com/acme/errors v0.9.2",
+		"go.exclude.0":        "github.com/spf13/cobra v1.2.0",
+		"go.retract.0":        "[v2.0.0, v2.0.1]",
+	}
+	if !reflect.DeepEqual(module.Annotations, wantAnnotations) {
+		t.Errorf("Read() annotations = %v, want %v", module.Annotations, wantAnnotations)
+	}
+
+	wantReport := `github.com/pkg/errors: version "v0.9.1+incompatible" changed to "v0.9.1-incompatible": must be a lowercase alphanumeric version
+go.mod:26: directive "godebug default=go1.21" dropped: unknown directive
+github.com/Acme/app/v2: namespace "github.com.Acme" changed to "github.com.acme": must be a lowercase alphanumeric identifier`
+	if got := report.String(); got != wantReport {
+		t.Errorf("Read() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func TestRead_errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		goMod string
+	}{
+		{"missing module directive", "go 1.17\n"},
+		{"invalid module directive", "module a b\n"},
+		{"invalid require directive", "module app\nrequire github.com/pkg/errors\n"},
+		{"invalid replace directive", "module app\nreplace github.com/pkg/errors v0.9.1\n"},
+		{"invalid exclude directive", "module app\nexclude github.com/pkg/errors\n"},
+		{"unterminated block", "module app\nrequire (\n"},
+		{"unterminated string", "module \"app\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Read(strings.NewReader(tt.goMod), "v1.0.0"); err == nil {
+				t.Errorf("Read() error = nil, want error")
+			}
+		})
+	}
+}
+
+func Test_coordinate(t *testing.T) {
+	tests := []struct {
+		path          string
+		wantNamespace string
+		wantName      string
+	}{
+		{"github.com/spf13/cobra", "github.com.spf13", "cobra"},
+		{"github.com/spf13/cobra/v2", "github.com.spf13", "cobra.v2"},
+		{"gopkg.in/yaml.v3", "gopkg.in", "yaml.v3"},
+		{"example", "", "example"},
+		{"v2", "", "v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := coordinate(tt.path, "v1.0.0")
+			if got.Namespace != tt.wantNamespace || got.Name != tt.wantName {
+				t.Errorf("coordinate() = %v/%v, want %v/%v", got.Namespace, got.Name, tt.wantNamespace, tt.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package gomod
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// directive is a single go.mod directive like "require golang.org/x/sys v0.1.0 // indirect".
+// Directives inside a block like "require ( ... )" carry the verb of the block.
+type directive struct {
+	// line is the 1-based line number.
+	line    int
+	verb    string
+	args    []string
+	comment string
+}
+
+// parse splits the go.mod file into directives.
+func parse(r io.Reader) ([]directive, error) {
+	var directives []directive
+	block := ""
+
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		tokens, comment, err := tokenize(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("go.mod:%d: %w", number, err)
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+
+		if block != "" {
+			if len(tokens) == 1 && tokens[0] == ")" {
+				block = ""
+				continue
+			}
+			directives = append(directives, directive{line: number, verb: block, args: tokens, comment: comment})
+			continue
+		}
+
+		if len(tokens) == 2 && tokens[1] == "(" {
+			block = tokens[0]
+			continue
+		}
+		directives = append(directives, directive{line: number, verb: tokens[0], args: tokens[1:], comment: comment})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("go.mod: %w", err)
+	}
+	if block != "" {
+		return nil, fmt.Errorf("go.mod: unterminated %s block", block)
+	}
+
+	return directives, nil
+}
+
+// tokenize splits the line into unquoted tokens and the trimmed text of a trailing // comment.
+func tokenize(line string) ([]string, string, error) {
+	var tokens []string
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(line[i:], "//"):
+			return tokens, strings.TrimSpace(line[i+2:]), nil
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"' || c == '`':
+			end := i + 1
+			for end < len(line) && line[end] != c {
+				if c == '"' && line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, "", fmt.Errorf("unterminated quoted string %s", line[i:])
+			}
+			token, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid quoted string %s", line[i:end+1])
+			}
+			tokens = append(tokens, token)
+			i = end + 1
+		default:
+			end := i
+			for end < len(line) && !strings.ContainsRune(" \t\r()\"`", rune(line[end])) && !strings.HasPrefix(line[end:], "//") {
+				end++
+			}
+			tokens = append(tokens, line[i:end])
+			i = end
+		}
+	}
+	return tokens, "", nil
+}
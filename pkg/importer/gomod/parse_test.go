@@ -0,0 +1,36 @@
+package gomod
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_tokenize(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantTokens  []string
+		wantComment string
+		wantErr     bool
+	}{
+		{"empty", "", nil, "", false},
+		{"comment only", "// comment", nil, "comment", false},
+		{"directive", "require github.com/pkg/errors v0.9.1", []string{"require", "github.com/pkg/errors", "v0.9.1"}, "", false},
+		{"trailing comment", "\tgithub.com/pkg/errors v0.9.1 // indirect", []string{"github.com/pkg/errors", "v0.9.1"}, "indirect", false},
+		{"block start without space", "require(", []string{"require", "("}, "", false},
+		{"quoted strings", "replace \"a b\" => `c`", []string{"replace", "a b", "=>", "c"}, "", false},
+		{"escaped quote", `module "a\"b"`, []string{"module", `a"b`}, "", false},
+		{"unterminated string", `module "app`, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, comment, err := tokenize(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tokenize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tokens, tt.wantTokens) || comment != tt.wantComment {
+				t.Errorf("tokenize() = %q, %q, want %q, %q", tokens, comment, tt.wantTokens, tt.wantComment)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Package importer provides the building blocks shared by importers, which convert
+// foreign formats like SBOMs and package manifests into modules.
+//
+// Foreign formats rarely map one-to-one onto modules. Importers therefore normalize identifiers
+// to satisfy the specification constraints and record every lossy transformation in a Report
+// instead of dropping information silently.
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Loss describes a lossy transformation applied while importing.
+type Loss struct {
+	// Source locates the affected element in the imported document, e.g. a bom-ref or package name.
+	Source string
+	// Field names the affected module field, e.g. "name" or "annotations".
+	Field string
+	// Original is the value as found in the imported document.
+	Original string
+	// Result is the value after the transformation. It is empty if the value was dropped
+	// and equals Original if the value was kept, although it could not be interpreted.
+	Result string
+	// Reason explains the transformation.
+	Reason string
+}
+
+// String returns a human-readable description of the loss.
+func (l Loss) String() string {
+	switch l.Result {
+	case "":
+		return fmt.Sprintf("%s: %s %q dropped: %s", l.Source, l.Field, l.Original, l.Reason)
+	case l.Original:
+		return fmt.Sprintf("%s: %s %q kept uninterpreted: %s", l.Source, l.Field, l.Original, l.Reason)
+	}
+	return fmt.Sprintf("%s: %s %q changed to %q: %s", l.Source, l.Field, l.Original, l.Result, l.Reason)
+}
+
+// Report collects the lossy transformations of an import.
+// The zero value is an empty report ready to use.
+type Report struct {
+	// Losses lists the lossy transformations in the order they were applied.
+	Losses []Loss
+}
+
+// Add records a lossy transformation.
+func (r *Report) Add(source string, field string, original string, result string, reason string) {
+	r.Losses = append(r.Losses, Loss{Source: source, Field: field, Original: original, Result: result, Reason: reason})
+}
+
+// Drop records a dropped value.
+func (r *Report) Drop(source string, field string, original string, reason string) {
+	r.Add(source, field, original, "", reason)
+}
+
+// Lossless reports whether no lossy transformations were recorded.
+func (r *Report) Lossless() bool {
+	return len(r.Losses) == 0
+}
+
+// String returns the losses, one per line.
+func (r *Report) String() string {
+	lines := make([]string, len(r.Losses))
+	for i, loss := range r.Losses {
+		lines[i] = loss.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NormalizeIdentifier normalizes the value like NormalizeIdentifier
+// and records the transformation if the value changed.
+func (r *Report) NormalizeIdentifier(source string, field string, value string) string {
+	return r.normalize(source, field, value, NormalizeIdentifier(value), "must be a lowercase alphanumeric identifier")
+}
+
+// NormalizeVersion normalizes the value like NormalizeVersion
+// and records the transformation if the value changed.
+func (r *Report) NormalizeVersion(source string, field string, value string) string {
+	return r.normalize(source, field, value, NormalizeVersion(value), "must be a lowercase alphanumeric version")
+}
+
+// NormalizeAnnotationValue normalizes the value like NormalizeAnnotationValue
+// and records the transformation if the value changed.
+func (r *Report) NormalizeAnnotationValue(source string, field string, value string) string {
+	return r.normalize(source, field, value, NormalizeAnnotationValue(value), "must not exceed the maximum annotation value length")
+}
+
+func (r *Report) normalize(source string, field string, value string, normalized string, reason string) string {
+	if normalized != value {
+		r.Add(source, field, value, normalized, reason)
+	}
+	return normalized
+}
+
+// AddDependency adds the dependency to the module unless the module already declares it with the same direction.
+func AddDependency(module *v1.Module, dependency *v1.ModuleDependency) {
+	for _, existing := range module.Dependencies {
+		if existing.Coordinate() == dependency.Coordinate() && existing.GetDirection() == dependency.GetDirection() {
+			return
+		}
+	}
+	module.Dependencies = append(module.Dependencies, dependency)
+}
+
+// UnknownFields returns the sorted names of the members of the JSON object,
+// which are not mapped to an exported field of the struct v by its json tags.
+// Like encoding/json, names are matched case-insensitively.
+func UnknownFields(data []byte, v interface{}) ([]string, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	known := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := cut(field.Tag.Get("json"), ",")
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+
+	var unknown []string
+	for name := range members {
+		if !known[strings.ToLower(name)] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+func cut(s string, sep string) (before string, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
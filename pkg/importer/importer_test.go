@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func TestReport(t *testing.T) {
+	var r Report
+	if !r.Lossless() {
+		t.Errorf("Lossless() = false, want true")
+	}
+
+	if got := r.NormalizeIdentifier("pkg:npm/app", "name", "app"); got != "app" || !r.Lossless() {
+		t.Errorf("NormalizeIdentifier() = %v, Lossless() = %v, want app, true", got, r.Lossless())
+	}
+	if got := r.NormalizeIdentifier("pkg:npm/App", "name", "App"); got != "app" {
+		t.Errorf("NormalizeIdentifier() = %v, want app", got)
+	}
+	if got := r.NormalizeVersion("pkg:npm/App", "version", "1.0.0+1"); got != "1.0.0-1" {
+		t.Errorf("NormalizeVersion() = %v, want 1.0.0-1", got)
+	}
+	r.Drop("pkg:npm/App", "annotations", "cdx:npm:package:development", "duplicate key")
+	r.Add("pkg:npm/App", "placeholder", "${version}", "${version}", "property is not defined")
+
+	want := `pkg:npm/App: name "App" changed to "app": must be a lowercase alphanumeric identifier
+pkg:npm/App: version "1.0.0+1" changed to "1.0.0-1": must be a lowercase alphanumeric version
+pkg:npm/App: annotations "cdx:npm:package:development" dropped: duplicate key
+pkg:npm/App: placeholder "${version}" kept uninterpreted: property is not defined`
+	if got := r.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+	if r.Lossless() {
+		t.Errorf("Lossless() = true, want false")
+	}
+}
+
+func TestAddDependency(t *testing.T) {
+	module := &v1.Module{}
+	downstream := &v1.ModuleDependency{Namespace: "acme", Name: "app", Type: "npm", Version: "1.0.0", Direction: v1.DependencyDirection_DOWNSTREAM.Enum()}
+
+	AddDependency(module, &v1.ModuleDependency{Namespace: "acme", Name: "app", Type: "npm", Version: "1.0.0"})
+	AddDependency(module, &v1.ModuleDependency{Namespace: "acme", Name: "app", Type: "npm", Version: "1.0.0"})
+	AddDependency(module, downstream)
+	AddDependency(module, downstream)
+
+	if len(module.Dependencies) != 2 {
+		t.Errorf("AddDependency() = %v, want 2 dependencies", module.Dependencies)
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	type value struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+		Type    string
+		Ignored string `json:"-"`
+		hidden  string
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		wantErr bool
+	}{
+		{"known fields", `{"name": "a", "version": "1", "Type": "b"}`, nil, false},
+		{"case-insensitive", `{"NAME": "a", "type": "b"}`, nil, false},
+		{"unknown fields", `{"name": "a", "licenses": [], "hashes": {}}`, []string{"hashes", "licenses"}, false},
+		{"ignored and unexported fields", `{"name": "a", "Ignored": "b", "hidden": "c"}`, []string{"Ignored", "hidden"}, false},
+		{"no object", `[]`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnknownFields([]byte(tt.data), &value{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnknownFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnknownFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,620 @@
+// Package toml is a minimal TOML reader sufficient for package manifests and lockfiles,
+// see https://toml.io/en/v1.0.0.
+//
+// Documents are decoded into nested values: tables become map[string]interface{}, arrays and
+// arrays of tables []interface{}, strings string, integers int64, floats float64 and booleans bool.
+// Dates and times are kept as string. Arrays and inline tables must not be nested deeper than
+// 10000 levels.
+package toml
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Parse decodes the TOML document.
+func Parse(data []byte) (map[string]interface{}, error) {
+	p := &parser{data: string(data), line: 1}
+	root := make(map[string]interface{})
+	if err := p.document(root); err != nil {
+		return nil, fmt.Errorf("toml: line %d: %w", p.line, err)
+	}
+	return root, nil
+}
+
+// Table returns the table stored at the dotted key path or nil.
+func Table(t map[string]interface{}, path ...string) map[string]interface{} {
+	for _, key := range path {
+		next, ok := t[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		t = next
+	}
+	return t
+}
+
+// String returns the string stored under the key or an empty string.
+func String(t map[string]interface{}, key string) string {
+	s, _ := t[key].(string)
+	return s
+}
+
+// Strings returns the strings of the array stored under the key. Other values are skipped.
+func Strings(t map[string]interface{}, key string) []string {
+	values, _ := t[key].([]interface{})
+	var result []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Tables returns the tables of the array of tables stored under the key. Other values are skipped.
+func Tables(t map[string]interface{}, key string) []map[string]interface{} {
+	values, _ := t[key].([]interface{})
+	var result []map[string]interface{}
+	for _, v := range values {
+		if table, ok := v.(map[string]interface{}); ok {
+			result = append(result, table)
+		}
+	}
+	return result
+}
+
+type parser struct {
+	data string
+	pos  int
+	line int
+	// defined tracks explicitly defined tables, which must not be defined twice.
+	defined map[string]bool
+	// depth is the number of arrays and inline tables enclosing the current position.
+	depth int
+}
+
+// maxDepth is the maximum nesting depth of arrays and inline tables.
+const maxDepth = 10000
+
+func (p *parser) document(root map[string]interface{}) error {
+	p.defined = make(map[string]bool)
+	current := root
+
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+
+		if p.peek() == '[' {
+			table, err := p.header(root)
+			if err != nil {
+				return err
+			}
+			current = table
+		} else if err := p.keyValue(current); err != nil {
+			return err
+		}
+
+		if err := p.endOfLine(); err != nil {
+			return err
+		}
+	}
+}
+
+// header parses [table] and [[array.of.tables]] headers and returns the table to fill.
+func (p *parser) header(root map[string]interface{}) (map[string]interface{}, error) {
+	array := strings.HasPrefix(p.data[p.pos:], "[[")
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+
+	p.skipSpace()
+	path, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if !strings.HasPrefix(p.data[p.pos:], closing) {
+		return nil, fmt.Errorf("table header must end with %s", closing)
+	}
+	p.pos += len(closing)
+
+	parent, err := descend(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	if array {
+		existing, ok := parent[last]
+		if !ok {
+			existing = []interface{}{}
+		}
+		tables, ok := existing.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("key %q is already defined", strings.Join(path, "."))
+		}
+		table := make(map[string]interface{})
+		parent[last] = append(tables, table)
+		return table, nil
+	}
+
+	name := strings.Join(path, "\x00")
+	if p.defined[name] {
+		return nil, fmt.Errorf("table %q is already defined", strings.Join(path, "."))
+	}
+	p.defined[name] = true
+
+	return descend(parent, []string{last})
+}
+
+// descend returns the table at the key path below t and creates missing tables.
+// If a key refers to an array of tables, its last table is used.
+func descend(t map[string]interface{}, path []string) (map[string]interface{}, error) {
+	for _, key := range path {
+		switch next := t[key].(type) {
+		case nil:
+			table := make(map[string]interface{})
+			t[key] = table
+			t = table
+		case map[string]interface{}:
+			t = next
+		case []interface{}:
+			if len(next) == 0 {
+				return nil, fmt.Errorf("key %q is not a table", key)
+			}
+			table, ok := next[len(next)-1].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("key %q is not a table", key)
+			}
+			t = table
+		default:
+			return nil, fmt.Errorf("key %q is not a table", key)
+		}
+	}
+	return t, nil
+}
+
+func (p *parser) keyValue(t map[string]interface{}) error {
+	path, err := p.key()
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	if p.eof() || p.peek() != '=' {
+		return fmt.Errorf("key %q must be followed by =", strings.Join(path, "."))
+	}
+	p.pos++
+	p.skipSpace()
+
+	value, err := p.value()
+	if err != nil {
+		return err
+	}
+
+	parent, err := descend(t, path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+	last := path[len(path)-1]
+	if _, exists := parent[last]; exists {
+		return fmt.Errorf("key %q is already defined", strings.Join(path, "."))
+	}
+	parent[last] = value
+	return nil
+}
+
+// key parses a possibly dotted key consisting of bare or quoted keys.
+func (p *parser) key() ([]string, error) {
+	var path []string
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return nil, errors.New("key must be set")
+		}
+
+		switch c := p.peek(); {
+		case c == '"':
+			s, err := p.basicString()
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, s)
+		case c == '\'':
+			s, err := p.literalString()
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, s)
+		default:
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, fmt.Errorf("invalid character %q in key", c)
+			}
+			path = append(path, p.data[start:p.pos])
+		}
+
+		p.skipSpace()
+		if p.eof() || p.peek() != '.' {
+			return path, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *parser) value() (interface{}, error) {
+	if p.eof() {
+		return nil, errors.New("value must be set")
+	}
+
+	switch c := p.peek(); {
+	case strings.HasPrefix(p.data[p.pos:], `"""`):
+		return p.multiLineString(`"""`)
+	case strings.HasPrefix(p.data[p.pos:], `'''`):
+		return p.multiLineString(`'''`)
+	case c == '"':
+		return p.basicString()
+	case c == '\'':
+		return p.literalString()
+	case c == '[':
+		return p.array()
+	case c == '{':
+		return p.inlineTable()
+	default:
+		return p.scalar()
+	}
+}
+
+func (p *parser) array() (interface{}, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	p.pos++
+	values := []interface{}{}
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil, errors.New("array must end with ]")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return values, nil
+		}
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		p.skipBlank()
+		if p.eof() {
+			return nil, errors.New("array must end with ]")
+		}
+		if p.peek() == ',' {
+			p.pos++
+		} else if p.peek() != ']' {
+			return nil, errors.New("array values must be separated by ,")
+		}
+	}
+}
+
+func (p *parser) inlineTable() (interface{}, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	p.pos++
+	table := make(map[string]interface{})
+	p.skipSpace()
+	if !p.eof() && p.peek() == '}' {
+		p.pos++
+		return table, nil
+	}
+
+	for {
+		if err := p.keyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.eof() {
+			return nil, errors.New("inline table must end with }")
+		}
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, errors.New("inline table values must be separated by ,")
+		}
+	}
+}
+
+// enter enters an array or inline table. It returns an error if it is nested deeper than maxDepth.
+func (p *parser) enter() error {
+	if p.depth == maxDepth {
+		return fmt.Errorf("arrays and inline tables must not be nested deeper than %d levels", maxDepth)
+	}
+	p.depth++
+	return nil
+}
+
+// leave leaves the array or inline table entered last.
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) scalar() (interface{}, error) {
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(",]}#\r\n", rune(p.peek())) {
+		p.pos++
+	}
+	token := strings.TrimSpace(p.data[start:p.pos])
+	// a space separates date and time, but ends other values
+	if i := strings.IndexAny(token, " \t"); i >= 0 && !isDateTime(token) {
+		p.pos = start + i
+		token = token[:i]
+	}
+
+	switch {
+	case token == "true":
+		return true, nil
+	case token == "false":
+		return false, nil
+	case token == "inf" || token == "+inf" || token == "-inf" || token == "nan" || token == "+nan" || token == "-nan":
+		return strconv.ParseFloat(strings.TrimPrefix(token, "+"), 64)
+	case isDateTime(token):
+		return token, nil
+	}
+
+	digits := strings.ReplaceAll(token, "_", "")
+	unsigned := strings.TrimLeft(digits, "+-")
+	switch {
+	case strings.HasPrefix(unsigned, "0x") || strings.HasPrefix(unsigned, "0o") || strings.HasPrefix(unsigned, "0b"):
+		if i, err := strconv.ParseInt(digits, 0, 64); err == nil {
+			return i, nil
+		}
+	case isDigits(unsigned) && (unsigned == "0" || unsigned[0] != '0'):
+		if i, err := strconv.ParseInt(digits, 10, 64); err == nil {
+			return i, nil
+		}
+	case strings.ContainsAny(unsigned, ".eE") && isDigit(unsigned):
+		if f, err := strconv.ParseFloat(digits, 64); err == nil {
+			return f, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid value %q", token)
+}
+
+func (p *parser) basicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", errors.New("string must end with \"")
+		}
+		c := p.peek()
+		switch c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *parser) literalString() (string, error) {
+	p.pos++
+	end := strings.IndexAny(p.data[p.pos:], "'\n")
+	if end < 0 || p.data[p.pos+end] != '\'' {
+		return "", errors.New("string must end with '")
+	}
+	s := p.data[p.pos : p.pos+end]
+	p.pos += end + 1
+	return s, nil
+}
+
+func (p *parser) multiLineString(delimiter string) (string, error) {
+	p.pos += len(delimiter)
+	// a newline immediately following the opening delimiter is trimmed
+	if strings.HasPrefix(p.data[p.pos:], "\r\n") {
+		p.pos += 2
+		p.line++
+	} else if strings.HasPrefix(p.data[p.pos:], "\n") {
+		p.pos++
+		p.line++
+	}
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", fmt.Errorf("string must end with %s", delimiter)
+		}
+		if strings.HasPrefix(p.data[p.pos:], delimiter) {
+			p.pos += len(delimiter)
+			// up to two additional quotes belong to the string
+			for i := 0; i < 2 && !p.eof() && p.peek() == delimiter[0]; i++ {
+				b.WriteByte(delimiter[0])
+				p.pos++
+			}
+			return b.String(), nil
+		}
+
+		c := p.peek()
+		switch {
+		case c == '\\' && delimiter == `"""`:
+			// a line ending backslash trims all following whitespace
+			rest := strings.TrimLeft(p.data[p.pos+1:], " \t\r")
+			if strings.HasPrefix(rest, "\n") {
+				p.pos++
+				for !p.eof() && strings.ContainsRune(" \t\r\n", rune(p.peek())) {
+					if p.peek() == '\n' {
+						p.line++
+					}
+					p.pos++
+				}
+				continue
+			}
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+		default:
+			if c == '\n' {
+				p.line++
+			}
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *parser) escape(b *strings.Builder) error {
+	if p.pos+1 >= len(p.data) {
+		return errors.New("invalid escape sequence")
+	}
+	c := p.data[p.pos+1]
+	p.pos += 2
+
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"':
+		b.WriteByte('"')
+	case '\\':
+		b.WriteByte('\\')
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if p.pos+n > len(p.data) {
+			return errors.New("invalid unicode escape sequence")
+		}
+		code, err := strconv.ParseUint(p.data[p.pos:p.pos+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return fmt.Errorf("invalid unicode escape sequence \\%c%s", c, p.data[p.pos:p.pos+n])
+		}
+		b.WriteRune(rune(code))
+		p.pos += n
+	default:
+		return fmt.Errorf("invalid escape sequence \\%c", c)
+	}
+	return nil
+}
+
+// endOfLine skips trailing whitespace and a comment and requires a newline or the end of the document.
+func (p *parser) endOfLine() error {
+	p.skipSpace()
+	p.skipComment()
+	if p.eof() {
+		return nil
+	}
+	if strings.HasPrefix(p.data[p.pos:], "\r\n") {
+		p.pos++
+	}
+	if p.peek() != '\n' {
+		return fmt.Errorf("unexpected %q", p.peek())
+	}
+	p.pos++
+	p.line++
+	return nil
+}
+
+// skipBlank skips whitespace, newlines and comments.
+func (p *parser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+			p.pos++
+		case '\n':
+			p.pos++
+			p.line++
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+func (p *parser) skipComment() {
+	if !p.eof() && p.peek() == '#' {
+		for !p.eof() && p.peek() != '\n' {
+			p.pos++
+		}
+	}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *parser) peek() byte {
+	return p.data[p.pos]
+}
+
+func isBareKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-'
+}
+
+// isDateTime reports whether the token looks like a date, a time or a date-time, e.g. 1979-05-27T07:32:00Z.
+func isDateTime(token string) bool {
+	if len(token) >= 10 && token[4] == '-' && token[7] == '-' && isDigits(token[:4]) {
+		return true
+	}
+	return len(token) >= 8 && token[2] == ':' && token[5] == ':' && isDigits(token[:2])
+}
+
+// isDigit reports whether s starts with a digit.
+func isDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
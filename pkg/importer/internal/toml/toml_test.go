@@ -0,0 +1,156 @@
+package toml
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	document := `# comment
+title = "TOML \"example\" \u00e9" # trailing comment
+literal = 'C:\path'
+multi = """
+first \
+  second"""
+raw = '''
+line 1
+line 2'''
+integers = [1, +2, -3, 1_000, 0x1F, 0o17, 0b11, 0]
+floats = [1.5, -2e3, inf]
+booleans = [true, false]
+date = 1979-05-27T07:32:00Z
+local = 1979-05-27 07:32:00
+nested = [
+  [1, 2], # comment
+  ["a", 'b'],
+]
+inline = { name = "x", version.major = 1 }
+"quoted key" = 1
+dotted.key = "value"
+
+[package]
+name = "app"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+
+[target.'cfg(unix)'.dependencies]
+libc = "0.2"
+
+[[bin]]
+name = "a"
+
+[[bin]]
+name = "b"
+
+[bin.extra]
+x = 1
+`
+
+	want := map[string]interface{}{
+		"title":    "TOML \"example\" é",
+		"literal":  `C:\path`,
+		"multi":    "first second",
+		"raw":      "line 1\nline 2",
+		"integers": []interface{}{int64(1), int64(2), int64(-3), int64(1000), int64(31), int64(15), int64(3), int64(0)},
+		"floats":   []interface{}{1.5, -2000.0, math.Inf(1)},
+		"booleans": []interface{}{true, false},
+		"date":     "1979-05-27T07:32:00Z",
+		"local":    "1979-05-27 07:32:00",
+		"nested": []interface{}{
+			[]interface{}{int64(1), int64(2)},
+			[]interface{}{"a", "b"},
+		},
+		"inline": map[string]interface{}{
+			"name":    "x",
+			"version": map[string]interface{}{"major": int64(1)},
+		},
+		"quoted key": int64(1),
+		"dotted":     map[string]interface{}{"key": "value"},
+		"package":    map[string]interface{}{"name": "app"},
+		"dependencies": map[string]interface{}{
+			"serde": map[string]interface{}{"version": "1.0", "features": []interface{}{"derive"}},
+		},
+		"target": map[string]interface{}{
+			"cfg(unix)": map[string]interface{}{
+				"dependencies": map[string]interface{}{"libc": "0.2"},
+			},
+		},
+		"bin": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b", "extra": map[string]interface{}{"x": int64(1)}},
+		},
+	}
+
+	got, err := Parse([]byte(document))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantErr  string
+	}{
+		{"missing equals", "key value", `toml: line 1: key "key" must be followed by =`},
+		{"missing value", "key =", "toml: line 1: value must be set"},
+		{"unterminated string", "key = \"value", `toml: line 1: string must end with "`},
+		{"unterminated array", "key = [1,\n2", "toml: line 2: array must end with ]"},
+		{"duplicate key", "a = 1\na = 2", `toml: line 2: key "a" is already defined`},
+		{"duplicate table", "[a]\n[a]", `toml: line 2: table "a" is already defined`},
+		{"invalid value", "a = yes", `toml: line 1: invalid value "yes"`},
+		{"leading zero", "a = 01", `toml: line 1: invalid value "01"`},
+		{"trailing characters", "a = 1 2", `toml: line 1: unexpected '2'`},
+		{"invalid escape", `a = "\x"`, `toml: line 1: invalid escape sequence \x`},
+		{"unterminated header", "[a", "toml: line 1: table header must end with ]"},
+		{"value as table", "a = 1\n[a.b]", `toml: line 2: key "a" is not a table`},
+		{"deeply nested arrays", "a = " + strings.Repeat("[", maxDepth+1), "toml: line 1: arrays and inline tables must not be nested deeper than 10000 levels"},
+		{"deeply nested inline tables", "a = " + strings.Repeat("{b = ", maxDepth+1), "toml: line 1: arrays and inline tables must not be nested deeper than 10000 levels"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.document))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	document, err := Parse([]byte(`
+[tool.poetry]
+name = "app"
+keywords = ["a", 1, "b"]
+
+[[package]]
+name = "x"
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	poetry := Table(document, "tool", "poetry")
+	if got := String(poetry, "name"); got != "app" {
+		t.Errorf("String() = %v, want app", got)
+	}
+	if got := String(poetry, "missing"); got != "" {
+		t.Errorf("String() = %v, want empty string", got)
+	}
+	if got := Strings(poetry, "keywords"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Strings() = %v, want [a b]", got)
+	}
+	if got := Table(document, "tool", "missing"); got != nil {
+		t.Errorf("Table() = %v, want nil", got)
+	}
+	if got := Tables(document, "package"); len(got) != 1 || String(got[0], "name") != "x" {
+		t.Errorf("Tables() = %v, want [map[name:x]]", got)
+	}
+}
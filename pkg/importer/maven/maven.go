@@ -0,0 +1,275 @@
+// Package maven imports Maven projects from pom.xml files, see https://maven.apache.org/pom.html.
+//
+// The groupId is mapped onto the namespace and the artifactId onto the name. Missing groupId and version
+// are inherited from the parent. Because dependencies cannot carry annotations, the original coordinates
+// and the dependency details are kept as module annotations:
+//
+//	maven.id                  the groupId and artifactId, e.g. "org.example:app"
+//	maven.packaging           the packaging, e.g. "war"
+//	maven.dependency.<i>      the groupId, artifactId and version of the i-th dependency
+//	maven.scope.<i>           the scope of the i-th dependency, e.g. "test"
+//	maven.classifier.<i>      the classifier of the i-th dependency, e.g. "sources"
+//	maven.type.<i>            the type of the i-th dependency, e.g. "test-jar"
+//	maven.optional.<i>        "true" if the i-th dependency is optional
+package maven
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Type is the module type of Maven artifacts.
+const Type = "maven"
+
+// maxPropertyDepth limits the nesting of property placeholders.
+const maxPropertyDepth = 32
+
+// maxExpandedLength limits the length of a value with expanded placeholders in bytes,
+// which protects against properties referencing other properties several times.
+const maxExpandedLength = 64 << 10
+
+var (
+	errNestedTooDeep   = fmt.Errorf("placeholders nested deeper than %d levels", maxPropertyDepth)
+	errExpandedTooLong = fmt.Errorf("expanded value exceeds %d bytes", maxExpandedLength)
+)
+
+type project struct {
+	GroupID              string       `xml:"groupId"`
+	ArtifactID           string       `xml:"artifactId"`
+	Version              string       `xml:"version"`
+	Packaging            string       `xml:"packaging"`
+	Parent               parent       `xml:"parent"`
+	Properties           properties   `xml:"properties"`
+	Dependencies         []dependency `xml:"dependencies>dependency"`
+	DependencyManagement []dependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+type parent struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+type properties struct {
+	Entries []property `xml:",any"`
+}
+
+type property struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type dependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Classifier string `xml:"classifier"`
+	Scope      string `xml:"scope"`
+	Optional   string `xml:"optional"`
+}
+
+// Read parses the pom.xml file and converts it into a module.
+//
+// Placeholders like ${junit.version} are resolved from the properties of the same POM and
+// the project.* properties, e.g. ${project.version}. Dependencies without version take it from
+// the dependencyManagement section of the same POM. All dependencies become UPSTREAM dependencies.
+// Identifiers are normalized to satisfy the specification constraints and all lossy transformations,
+// including unresolvable placeholders, are recorded in the returned report.
+func Read(r io.Reader) (*v1.Module, *importer.Report, error) {
+	var p project
+	if err := xml.NewDecoder(r).Decode(&p); err != nil {
+		return nil, nil, fmt.Errorf("pom.xml: %w", err)
+	}
+
+	if p.GroupID == "" {
+		p.GroupID = p.Parent.GroupID
+	}
+	if p.Version == "" {
+		p.Version = p.Parent.Version
+	}
+
+	report := &importer.Report{}
+	res := newResolver(p, report)
+
+	groupID, artifactID, version := res.resolve(p.GroupID), res.resolve(p.ArtifactID), res.resolve(p.Version)
+	if artifactID == "" {
+		return nil, nil, errors.New("pom.xml: artifactId must be set")
+	}
+
+	id := groupID + ":" + artifactID
+	c, ok := report.Coordinate(id, "", v1.Coordinate{Namespace: groupID, Name: artifactID, Type: Type, Version: version})
+	if !ok {
+		return nil, nil, fmt.Errorf("pom.xml: artifactId %q: no valid name can be derived", artifactID)
+	}
+
+	module := c.Module()
+	module.Annotations = map[string]string{"maven.id": id}
+	if p.Packaging != "" {
+		module.Annotations["maven.packaging"] = res.resolve(p.Packaging)
+	}
+
+	managed := make(map[string]string)
+	for _, d := range p.DependencyManagement {
+		managed[res.resolve(d.GroupID)+":"+res.resolve(d.ArtifactID)] = res.resolve(d.Version)
+	}
+
+	for _, d := range p.Dependencies {
+		groupID, artifactID, version := res.resolve(d.GroupID), res.resolve(d.ArtifactID), res.resolve(d.Version)
+		id := groupID + ":" + artifactID
+		if version == "" {
+			version = managed[id]
+		}
+
+		c, ok := report.Coordinate(id, "", v1.Coordinate{Namespace: groupID, Name: artifactID, Type: Type, Version: version})
+		if !ok {
+			report.Drop(id, "dependency", id+":"+version, "no valid name can be derived")
+			continue
+		}
+
+		i := strconv.Itoa(len(module.Dependencies))
+		module.Dependencies = append(module.Dependencies, c.Dependency())
+		module.Annotations["maven.dependency."+i] = report.NormalizeAnnotationValue(id, "annotations.maven.dependency", id+":"+version)
+		for _, detail := range []struct {
+			key   string
+			value string
+		}{
+			{"maven.scope." + i, d.Scope},
+			{"maven.classifier." + i, d.Classifier},
+			{"maven.type." + i, d.Type},
+			{"maven.optional." + i, d.Optional},
+		} {
+			if value := res.resolve(detail.value); value != "" {
+				module.Annotations[detail.key] = report.NormalizeAnnotationValue(id, "annotations."+detail.key, value)
+			}
+		}
+	}
+
+	return module, report, nil
+}
+
+// resolver resolves ${property} placeholders.
+type resolver struct {
+	properties map[string]string
+	report     *importer.Report
+	// resolved caches the expanded properties, so that each property is expanded only once.
+	resolved map[string]resolvedProperty
+	// resolving holds the properties currently being expanded to detect cyclic definitions.
+	resolving map[string]bool
+}
+
+type resolvedProperty struct {
+	value string
+	err   error
+}
+
+func newResolver(p project, report *importer.Report) *resolver {
+	res := &resolver{
+		properties: map[string]string{
+			"project.groupId":           p.GroupID,
+			"project.artifactId":        p.ArtifactID,
+			"project.version":           p.Version,
+			"project.packaging":         p.Packaging,
+			"project.parent.groupId":    p.Parent.GroupID,
+			"project.parent.artifactId": p.Parent.ArtifactID,
+			"project.parent.version":    p.Parent.Version,
+		},
+		report:    report,
+		resolved:  make(map[string]resolvedProperty),
+		resolving: make(map[string]bool),
+	}
+	for k, v := range res.properties {
+		res.properties["pom."+strings.TrimPrefix(k, "project.")] = v
+	}
+	for _, entry := range p.Properties.Entries {
+		res.properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+	return res
+}
+
+// resolve trims the value and replaces all placeholders, which refer to defined properties.
+// Unresolvable placeholders are kept and reported.
+func (res *resolver) resolve(value string) string {
+	value = strings.TrimSpace(value)
+	resolved, err := res.expand(value)
+	if err != nil {
+		res.report.Add("pom.xml", "placeholder", value, resolved, err.Error())
+	}
+	return resolved
+}
+
+// expand replaces the placeholders of the value. If the placeholders are nested deeper than maxPropertyDepth
+// or the expanded value exceeds maxExpandedLength, the value is returned unchanged.
+func (res *resolver) expand(value string) (string, error) {
+	original := value
+
+	var b strings.Builder
+	var firstErr error
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(value[start:], "}")
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString(value[:start])
+		expanded, err := res.property(value[start+2 : end])
+		if err == errNestedTooDeep || err == errExpandedTooLong {
+			return original, err
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		b.WriteString(expanded)
+		if b.Len() > maxExpandedLength {
+			return original, errExpandedTooLong
+		}
+		value = value[end+1:]
+	}
+	b.WriteString(value)
+
+	if b.Len() > maxExpandedLength {
+		return original, errExpandedTooLong
+	}
+	return b.String(), firstErr
+}
+
+// property returns the expanded value of the named property.
+// Undefined and cyclic properties are kept as placeholder.
+func (res *resolver) property(name string) (string, error) {
+	placeholder := "${" + name + "}"
+	definition, ok := res.properties[name]
+	if !ok {
+		return placeholder, fmt.Errorf("property %q is not defined", name)
+	}
+	if resolved, ok := res.resolved[name]; ok {
+		return resolved.value, resolved.err
+	}
+	if res.resolving[name] {
+		return placeholder, fmt.Errorf("property %q is defined cyclically", name)
+	}
+	if len(res.resolving) >= maxPropertyDepth {
+		return placeholder, errNestedTooDeep
+	}
+
+	res.resolving[name] = true
+	value, err := res.expand(definition)
+	delete(res.resolving, name)
+	if err == errNestedTooDeep || err == errExpandedTooLong {
+		value = placeholder
+	}
+
+	res.resolved[name] = resolvedProperty{value: value, err: err}
+	return value, err
+}
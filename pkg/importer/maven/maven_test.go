@@ -0,0 +1,234 @@
+package maven
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+)
+
+const pomXML = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <parent>
+    <groupId>org.Example</groupId>
+    <artifactId>parent</artifactId>
+    <version>2.1.0</version>
+  </parent>
+  <artifactId>My_Service</artifactId>
+  <packaging>war</packaging>
+
+  <properties>
+    <junit.version>5.9.2</junit.version>
+    <jackson.major>2.15</jackson.major>
+    <jackson.version>${jackson.major}.0</jackson.version>
+  </properties>
+
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.slf4j</groupId>
+        <artifactId>slf4j-api</artifactId>
+        <version>2.0.7</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+
+  <dependencies>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+      <version>${jackson.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>org.junit.jupiter</groupId>
+      <artifactId>junit-jupiter</artifactId>
+      <version>${junit.version}</version>
+      <scope>test</scope>
+    </dependency>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>common</artifactId>
+      <version>${project.version}</version>
+      <classifier>tests</classifier>
+      <type>test-jar</type>
+      <optional>true</optional>
+    </dependency>
+    <dependency>
+      <groupId>org.other</groupId>
+      <artifactId>unknown</artifactId>
+      <version>${missing.version}</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+func TestRead(t *testing.T) {
+	module, report, err := Read(strings.NewReader(pomXML))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if got := module.Coordinate().String(); got != "org.example/my-service:maven@2.1.0" {
+		t.Errorf("Read() = %v, want org.example/my-service:maven@2.1.0", got)
+	}
+	if err := module.ValidateAll(); err != nil {
+		t.Errorf("Read() module is invalid: %v", err)
+	}
+
+	var dependencies []string
+	for _, d := range module.Dependencies {
+		dependencies = append(dependencies, d.Coordinate().String())
+	}
+	wantDependencies := []string{
+		"com.fasterxml.jackson.core/jackson-databind:maven@2.15.0",
+		"org.slf4j/slf4j-api:maven@2.0.7",
+		"org.junit.jupiter/junit-jupiter:maven@5.9.2",
+		"org.example/common:maven@2.1.0",
+		"org.other/unknown:maven@missing.version",
+	}
+	if !reflect.DeepEqual(dependencies, wantDependencies) {
+		t.Errorf("Read() dependencies = %v, want %v", dependencies, wantDependencies)
+	}
+
+	wantAnnotations := map[string]string{
+		"maven.id":           "org.Example:My_Service",
+		"maven.packaging":    "war",
+		"maven.dependency.0": "com.fasterxml.jackson.core:jackson-databind:2.15.0",
+		"maven.dependency.1": "org.slf4j:slf4j-api:2.0.7",
+		"maven.dependency.2": "org.junit.jupiter:junit-jupiter:5.9.2",
+		"maven.scope.2":      "test",
+		"maven.dependency.3": "org.Example:common:2.1.0",
+		"maven.classifier.3": "tests",
+		"maven.type.3":       "test-jar",
+		"maven.optional.3":   "true",
+		"maven.dependency.4": "org.other:unknown:${missing.version}",
+	}
+	if !reflect.DeepEqual(module.Annotations, wantAnnotations) {
+		t.Errorf("Read() annotations = %v, want %v", module.Annotations, wantAnnotations)
+	}
+
+	wantReport := `org.Example:My_Service: namespace "org.Example" changed to "org.example": must be a lowercase alphanumeric identifier
+org.Example:My_Service: name "My_Service" changed to "my-service": must be a lowercase alphanumeric identifier
+org.Example:common: namespace "org.Example" changed to "org.example": must be a lowercase alphanumeric identifier
+pom.xml: placeholder "${missing.version}" kept uninterpreted: property "missing.version" is not defined
+org.other:unknown: version "${missing.version}" changed to "missing.version": must be a lowercase alphanumeric version`
+	if got := report.String(); got != wantReport {
+		t.Errorf("Read() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func TestRead_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		pom  string
+	}{
+		{"invalid XML", `<project>`},
+		{"missing artifactId", `<project><groupId>org.example</groupId><version>1.0</version></project>`},
+		{"invalid artifactId", `<project><groupId>org.example</groupId><artifactId>___</artifactId><version>1.0</version></project>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Read(strings.NewReader(tt.pom)); err == nil {
+				t.Errorf("Read() error = nil, want error")
+			}
+		})
+	}
+}
+
+func Test_resolver_resolve(t *testing.T) {
+	p := project{GroupID: "org.example", Version: "1.0"}
+	p.Properties.Entries = []property{
+		{XMLName: xmlName("a"), Value: "${b}"},
+		{XMLName: xmlName("b"), Value: " value "},
+		{XMLName: xmlName("cycle"), Value: "${cycle}"},
+		{XMLName: xmlName("ping"), Value: "${pong}"},
+		{XMLName: xmlName("pong"), Value: "${ping}"},
+	}
+
+	tests := []struct {
+		value      string
+		want       string
+		wantLosses int
+	}{
+		{"plain", "plain", 0},
+		{"${a}", "value", 0},
+		{"${project.version}-${pom.groupId}", "1.0-org.example", 0},
+		{"${missing}", "${missing}", 1},
+		{"${unterminated", "${unterminated", 0},
+		{"${cycle}", "${cycle}", 1},
+		{"${ping}", "${ping}", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			res := newResolver(p, &importer.Report{})
+			if got := res.resolve(tt.value); got != tt.want {
+				t.Errorf("resolve() = %v, want %v", got, tt.want)
+			}
+			if got := len(res.report.Losses); got != tt.wantLosses {
+				t.Errorf("resolve() losses = %d, want %d", got, tt.wantLosses)
+			}
+		})
+	}
+}
+
+func Test_resolver_resolve_limits(t *testing.T) {
+	// each property references the previous one twice, so that the expanded value doubles per level
+	doubling := project{}
+	doubling.Properties.Entries = []property{{XMLName: xmlName("p0"), Value: "x"}}
+	for i := 1; i <= 24; i++ {
+		doubling.Properties.Entries = append(doubling.Properties.Entries, property{
+			XMLName: xmlName(fmt.Sprintf("p%d", i)),
+			Value:   fmt.Sprintf("${p%d}${p%d}", i-1, i-1),
+		})
+	}
+
+	// each property references the previous one once
+	chain := project{}
+	chain.Properties.Entries = []property{{XMLName: xmlName("c0"), Value: "x"}}
+	for i := 1; i <= 64; i++ {
+		chain.Properties.Entries = append(chain.Properties.Entries, property{
+			XMLName: xmlName(fmt.Sprintf("c%d", i)),
+			Value:   fmt.Sprintf("${c%d}", i-1),
+		})
+	}
+
+	tests := []struct {
+		name    string
+		project project
+		value   string
+		want    string
+		wantErr string
+	}{
+		{"is short enough", doubling, "${p4}", strings.Repeat("x", 16), ""},
+		{"is too long", doubling, "${p24}", "${p24}", "expanded value exceeds 65536 bytes"},
+		{"is nested shallow enough", chain, "${c16}", "x", ""},
+		{"is nested too deep", chain, "${c64}", "${c64}", "placeholders nested deeper than 32 levels"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := newResolver(tt.project, &importer.Report{})
+			if got := res.resolve(tt.value); got != tt.want {
+				t.Errorf("resolve() = %.32v, want %.32v", got, tt.want)
+			}
+
+			var reasons []string
+			for _, loss := range res.report.Losses {
+				reasons = append(reasons, loss.Reason)
+			}
+			if got := strings.Join(reasons, "; "); got != tt.wantErr {
+				t.Errorf("resolve() losses = %v, want %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func xmlName(local string) xml.Name {
+	return xml.Name{Local: local}
+}
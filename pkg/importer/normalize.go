@@ -0,0 +1,110 @@
+package importer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxIdentifierLength      = 63
+	maxAnnotationValueLength = 253
+)
+
+// NormalizeIdentifier normalizes the value to satisfy the constraints of module namespaces, names, types
+// and annotation keys: it must consist of at most 63 lowercase alphanumeric characters, '-' or '.',
+// start with a lowercase alphabetic character and end with a lowercase alphanumeric character.
+//
+// Valid values are returned unchanged. Otherwise, the value is lowercased and each run of other characters
+// is replaced by a single '-'. Leading and trailing characters violating the constraints are trimmed and values
+// starting with a digit are prefixed with "x-". An empty string is returned if no identifier can be derived.
+func NormalizeIdentifier(value string) string {
+	if isIdentifier(value, isLowercaseAlphabetic) {
+		return value
+	}
+
+	normalized := normalize(value)
+	if normalized != "" && !isLowercaseAlphabetic(normalized[0]) {
+		normalized = truncate("x-" + normalized)
+	}
+	return normalized
+}
+
+// NormalizeVersion normalizes the value to satisfy the constraints of module version names,
+// which equal those of NormalizeIdentifier except that a version may start with a digit.
+// An empty string is returned if no version can be derived.
+func NormalizeVersion(value string) string {
+	if isIdentifier(value, isLowercaseAlphanumeric) {
+		return value
+	}
+
+	return normalize(value)
+}
+
+// NormalizeAnnotationValue truncates the value to the maximum annotation value length of 253 bytes,
+// whereas multi-byte characters are not split.
+func NormalizeAnnotationValue(value string) string {
+	if len(value) <= maxAnnotationValueLength {
+		return value
+	}
+
+	end := maxAnnotationValueLength
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+	return value[:end]
+}
+
+// normalize lowercases the value, replaces each run of invalid characters by a single '-'
+// and trims leading and trailing '-' and '.'.
+func normalize(value string) string {
+	var b strings.Builder
+	replaced := false
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if isLowercaseAlphanumeric(c) || c == '-' || c == '.' {
+			b.WriteByte(c)
+			replaced = false
+			continue
+		}
+		if !replaced {
+			b.WriteByte('-')
+			replaced = true
+		}
+	}
+
+	normalized := strings.TrimLeftFunc(b.String(), func(r rune) bool {
+		return r == '-' || r == '.'
+	})
+	return truncate(normalized)
+}
+
+// truncate shortens the value to the maximum identifier length and trims trailing '-' and '.'.
+func truncate(value string) string {
+	if len(value) > maxIdentifierLength {
+		value = value[:maxIdentifierLength]
+	}
+	return strings.TrimRight(value, "-.")
+}
+
+func isIdentifier(value string, isStart func(byte) bool) bool {
+	if len(value) == 0 || len(value) > maxIdentifierLength {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if !isLowercaseAlphanumeric(value[i]) && value[i] != '-' && value[i] != '.' {
+			return false
+		}
+	}
+	return isStart(value[0]) && isLowercaseAlphanumeric(value[len(value)-1])
+}
+
+func isLowercaseAlphabetic(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func isLowercaseAlphanumeric(c byte) bool {
+	return isLowercaseAlphabetic(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"valid", "com.example", "com.example"},
+		{"valid with double dash", "a--b", "a--b"},
+		{"uppercase", "Com.Example", "com.example"},
+		{"scoped npm package", "@angular/core", "angular-core"},
+		{"runs of invalid characters", "a_/_b", "a-b"},
+		{"leading digit", "7zip", "x-7zip"},
+		{"trailing dash", "name_", "name"},
+		{"unicode", "café", "caf"},
+		{"too long", strings.Repeat("a", 60) + "_bcd", strings.Repeat("a", 60) + "-bc"},
+		{"too long with trailing dash", strings.Repeat("a", 62) + "_b", strings.Repeat("a", 62)},
+		{"nothing left", "@@@", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeIdentifier(tt.value); got != tt.want {
+				t.Errorf("NormalizeIdentifier() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"valid", "v1.0.0", "v1.0.0"},
+		{"leading digit", "1.0.0", "1.0.0"},
+		{"build metadata", "1.0.0+build.1", "1.0.0-build.1"},
+		{"uppercase", "1.0.0-RC1", "1.0.0-rc1"},
+		{"leading dot", ".1", "1"},
+		{"nothing left", "+", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeVersion(tt.value); got != tt.want {
+				t.Errorf("NormalizeVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeAnnotationValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"short", "value", "value"},
+		{"maximum length", strings.Repeat("a", 253), strings.Repeat("a", 253)},
+		{"too long", strings.Repeat("a", 254), strings.Repeat("a", 253)},
+		{"multi-byte character at boundary", strings.Repeat("a", 252) + "é", strings.Repeat("a", 252)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeAnnotationValue(tt.value); got != tt.want {
+				t.Errorf("NormalizeAnnotationValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
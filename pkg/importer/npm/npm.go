@@ -0,0 +1,239 @@
+// Package npm imports npm packages from package.json manifests and package-lock.json lockfiles,
+// see https://docs.npmjs.com/cli/configuring-npm/package-json.
+//
+// Scoped packages like @angular/core are mapped onto the namespace angular and the name core,
+// unscoped packages onto the namespace DefaultNamespace. Because dependencies cannot carry annotations,
+// the original names and dependency groups are kept as module annotations:
+//
+//	npm.name                  the package name
+//	npm.dependency.<i>        the package name and version range of the i-th dependency
+//	npm.group.<i>             the comma-separated dependency groups of the i-th dependency:
+//	                          prod, dev, peer or optional
+package npm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+const (
+	// Type is the module type of npm packages.
+	Type = "npm"
+	// DefaultNamespace is the namespace of unscoped packages.
+	DefaultNamespace = "npm"
+)
+
+// Dependency groups as recorded in the npm.group.<i> annotations.
+const (
+	GroupProd     = "prod"
+	GroupDev      = "dev"
+	GroupPeer     = "peer"
+	GroupOptional = "optional"
+)
+
+type manifest struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+type lockfile struct {
+	LockfileVersion int                        `json:"lockfileVersion"`
+	Packages        map[string]lockfilePackage `json:"packages"`
+}
+
+type lockfilePackage struct {
+	Version string `json:"version"`
+}
+
+// Read parses the package.json manifest and the optional package-lock.json lockfile and converts them
+// into a module. The lockfile must have version 2 or 3 and may be nil. The module version is taken
+// from the manifest or alternatively from the lockfile.
+//
+// All dependency groups become UPSTREAM dependencies, whereas a package listed in several groups
+// becomes a single dependency. Dependencies use the version resolved by the lockfile, if any,
+// and otherwise the given version or the lower bound of the given version range like ^1.2.0
+// or >=1 <2. Identifiers are normalized to satisfy the specification constraints and all lossy
+// transformations are recorded in the returned report.
+func Read(manifestReader io.Reader, lockfileReader io.Reader) (*v1.Module, *importer.Report, error) {
+	var m manifest
+	if err := json.NewDecoder(manifestReader).Decode(&m); err != nil {
+		return nil, nil, fmt.Errorf("package.json: %w", err)
+	}
+	if m.Name == "" {
+		return nil, nil, errors.New("package.json: name must be set")
+	}
+
+	var lock lockfile
+	if lockfileReader != nil {
+		if err := json.NewDecoder(lockfileReader).Decode(&lock); err != nil {
+			return nil, nil, fmt.Errorf("package-lock.json: %w", err)
+		}
+		if lock.LockfileVersion != 2 && lock.LockfileVersion != 3 {
+			return nil, nil, fmt.Errorf("package-lock.json: lockfileVersion %d is not supported", lock.LockfileVersion)
+		}
+	}
+
+	report := &importer.Report{}
+
+	version := m.Version
+	if root, ok := lock.Packages[""]; ok && version == "" {
+		version = root.Version
+	}
+
+	c, ok := report.Coordinate(m.Name, "", coordinate(m.Name, version))
+	if !ok {
+		return nil, nil, fmt.Errorf("package.json: name %q: no valid name can be derived", m.Name)
+	}
+	module := c.Module()
+	module.Annotations = map[string]string{"npm.name": m.Name}
+
+	groups := []struct {
+		name         string
+		dependencies map[string]string
+	}{
+		{GroupProd, m.Dependencies},
+		{GroupDev, m.DevDependencies},
+		{GroupPeer, m.PeerDependencies},
+		{GroupOptional, m.OptionalDependencies},
+	}
+
+	indexes := make(map[string]int)
+	for _, group := range groups {
+		for _, name := range sortedKeys(group.dependencies) {
+			if i, ok := indexes[name]; ok {
+				key := "npm.group." + strconv.Itoa(i)
+				module.Annotations[key] += "," + group.name
+				continue
+			}
+
+			versionRange := group.dependencies[name]
+			version := versionRange
+			if resolved, ok := lock.Packages["node_modules/"+name]; ok && resolved.Version != "" {
+				version = resolved.Version
+			} else if !isExactVersion(versionRange) {
+				if lower, ok := lowerBound(versionRange); ok {
+					report.Add(name, "version", versionRange, lower, "version range cannot be represented, using lower bound")
+					version = lower
+				}
+			}
+
+			c, ok := report.Coordinate(name, "", coordinate(name, version))
+			if !ok {
+				report.Drop(name, "dependency", name+" "+versionRange, "no valid name can be derived")
+				continue
+			}
+
+			i := len(module.Dependencies)
+			indexes[name] = i
+			module.Dependencies = append(module.Dependencies, c.Dependency())
+			module.Annotations["npm.dependency."+strconv.Itoa(i)] = report.NormalizeAnnotationValue(name, "annotations.npm.dependency", name+" "+versionRange)
+			module.Annotations["npm.group."+strconv.Itoa(i)] = group.name
+		}
+	}
+
+	return module, report, nil
+}
+
+// isExactVersion matches exact versions like 1.2.3 or =v1.2.3-rc.1+build.
+var isExactVersion = regexp.MustCompile(`^=?v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`).MatchString
+
+// rangeVersion matches the version of a range comparator, which may be partial like 1.2 or contain wildcards like 1.x.
+var rangeVersion = regexp.MustCompile(`^v?(\d+)(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// lowerBound returns the smallest version of the version range, e.g. 1.2.0 for ^1.2 or 1.0.0 for >=1 <2 || ^3.
+// Partial versions and wildcards are completed with zeros and ranges without lower bound like <2 yield 0.0.0.
+// It returns false if the value is no version range, e.g. a tag like latest or a URL.
+func lowerBound(versionRange string) (string, bool) {
+	zero, _ := v1.ParseVersion("0.0.0", v1.VersionSchemaSemVer)
+
+	var lowest *v1.Version
+	for _, alternative := range strings.Split(versionRange, "||") {
+		fields := strings.Fields(alternative)
+		lower := zero
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			// the upper bound of hyphen ranges like 1.2.3 - 2.3.4 is skipped
+			if field == "-" {
+				i++
+				continue
+			}
+
+			operator := ""
+			for _, op := range []string{">=", "<=", "~>", ">", "<", "=", "^", "~"} {
+				if strings.HasPrefix(field, op) {
+					operator, field = op, field[len(op):]
+					break
+				}
+			}
+			if field == "" && operator != "" && i+1 < len(fields) {
+				i++
+				field = fields[i]
+			}
+			if field == "" || field == "*" || field == "x" || field == "X" {
+				continue
+			}
+
+			match := rangeVersion.FindStringSubmatch(field)
+			if match == nil {
+				return "", false
+			}
+			version, err := v1.ParseVersion(match[1]+"."+zeroWildcard(match[2])+"."+zeroWildcard(match[3])+match[4], v1.VersionSchemaSemVer)
+			if err != nil {
+				return "", false
+			}
+			if operator != "<" && operator != "<=" && version.Compare(lower) > 0 {
+				lower = version
+			}
+		}
+		if lowest == nil || lower.Less(*lowest) {
+			lowest = &lower
+		}
+	}
+	return lowest.String(), true
+}
+
+func zeroWildcard(component string) string {
+	if component == "" || component == "x" || component == "X" || component == "*" {
+		return "0"
+	}
+	return component
+}
+
+// coordinate maps the package name onto namespace and name.
+func coordinate(name string, version string) v1.Coordinate {
+	namespace := DefaultNamespace
+	if strings.HasPrefix(name, "@") {
+		if i := strings.Index(name, "/"); i > 0 {
+			namespace, name = name[1:i], name[i+1:]
+		}
+	}
+
+	return v1.Coordinate{
+		Namespace: namespace,
+		Name:      name,
+		Type:      Type,
+		Version:   version,
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
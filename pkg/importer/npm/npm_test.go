@@ -0,0 +1,188 @@
+package npm
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const packageJSON = `{
+  "name": "@acme/web-app",
+  "dependencies": {
+    "@angular/core": "^16.0.0",
+    "lodash": "~4.17.0"
+  },
+  "devDependencies": {
+    "typescript": "^5.0.0",
+    "@angular/core": "^16.0.0"
+  },
+  "peerDependencies": {
+    "rxjs": "^7.0.0"
+  },
+  "optionalDependencies": {
+    "Fsevents": "2.3.2"
+  }
+}`
+
+const packageLockJSON = `{
+  "name": "@acme/web-app",
+  "version": "1.4.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "@acme/web-app", "version": "1.4.0"},
+    "node_modules/@angular/core": {"version": "16.2.1"},
+    "node_modules/lodash": {"version": "4.17.21"},
+    "node_modules/typescript": {"version": "5.1.6", "dev": true},
+    "node_modules/Fsevents": {"version": "2.3.2", "optional": true}
+  }
+}`
+
+func TestRead(t *testing.T) {
+	module, report, err := Read(strings.NewReader(packageJSON), strings.NewReader(packageLockJSON))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if got := module.Coordinate().String(); got != "acme/web-app:npm@1.4.0" {
+		t.Errorf("Read() = %v, want acme/web-app:npm@1.4.0", got)
+	}
+	if err := module.ValidateAll(); err != nil {
+		t.Errorf("Read() module is invalid: %v", err)
+	}
+
+	var dependencies []string
+	for _, d := range module.Dependencies {
+		dependencies = append(dependencies, d.Coordinate().String())
+	}
+	wantDependencies := []string{
+		"angular/core:npm@16.2.1",
+		"npm/lodash:npm@4.17.21",
+		"npm/typescript:npm@5.1.6",
+		"npm/rxjs:npm@7.0.0",
+		"npm/fsevents:npm@2.3.2",
+	}
+	if !reflect.DeepEqual(dependencies, wantDependencies) {
+		t.Errorf("Read() dependencies = %v, want %v", dependencies, wantDependencies)
+	}
+
+	wantAnnotations := map[string]string{
+		"npm.name":         "@acme/web-app",
+		"npm.dependency.0": "@angular/core ^16.0.0",
+		"npm.group.0":      "prod,dev",
+		"npm.dependency.1": "lodash ~4.17.0",
+		"npm.group.1":      "prod",
+		"npm.dependency.2": "typescript ^5.0.0",
+		"npm.group.2":      "dev",
+		"npm.dependency.3": "rxjs ^7.0.0",
+		"npm.group.3":      "peer",
+		"npm.dependency.4": "Fsevents 2.3.2",
+		"npm.group.4":      "optional",
+	}
+	if !reflect.DeepEqual(module.Annotations, wantAnnotations) {
+		t.Errorf("Read() annotations = %v, want %v", module.Annotations, wantAnnotations)
+	}
+
+	wantReport := `rxjs: version "^7.0.0" changed to "7.0.0": version range cannot be represented, using lower bound
+Fsevents: name "Fsevents" changed to "fsevents": must be a lowercase alphanumeric identifier`
+	if got := report.String(); got != wantReport {
+		t.Errorf("Read() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func TestRead_withoutLockfile(t *testing.T) {
+	module, report, err := Read(strings.NewReader(`{"name": "app", "version": "1.0.0", "dependencies": {"lodash": "~4.17.0"}}`), nil)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if got := module.Coordinate().String(); got != "npm/app:npm@1.0.0" {
+		t.Errorf("Read() = %v, want npm/app:npm@1.0.0", got)
+	}
+	if len(module.Dependencies) != 1 || module.Dependencies[0].Version != "4.17.0" {
+		t.Errorf("Read() dependencies = %v, want lodash 4.17.0", module.Dependencies)
+	}
+	if want := `lodash: version "~4.17.0" changed to "4.17.0": version range cannot be represented, using lower bound`; report.String() != want {
+		t.Errorf("Read() report =\n%s\nwant\n%s", report, want)
+	}
+}
+
+func Test_lowerBound(t *testing.T) {
+	tests := []struct {
+		versionRange string
+		want         string
+		wantOK       bool
+	}{
+		{"^1.2.3", "1.2.3", true},
+		{"~1.2", "1.2.0", true},
+		{">=1 <2", "1.0.0", true},
+		{">= 1.2.0 < 2.0.0", "1.2.0", true},
+		{"<2.0.0", "0.0.0", true},
+		{">1.0.0 >=1.5.0", "1.5.0", true},
+		{"1.x", "1.0.0", true},
+		{"1.2.*", "1.2.0", true},
+		{"*", "0.0.0", true},
+		{"", "0.0.0", true},
+		{"1.2.3 - 2.3.4", "1.2.3", true},
+		{"^3.0.0 || ^2.1.0-rc.1", "2.1.0-rc.1", true},
+		{"v1.2.3", "1.2.3", true},
+		{"latest", "", false},
+		{"file:../lib", "", false},
+		{"github:user/repo", "", false},
+		{"npm:other@^1.0.0", "", false},
+		{"^01.2.3", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.versionRange, func(t *testing.T) {
+			got, ok := lowerBound(tt.versionRange)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("lowerBound() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRead_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		lockfile string
+	}{
+		{"invalid manifest", `{`, ""},
+		{"missing name", `{"version": "1.0.0"}`, ""},
+		{"invalid name", `{"name": "@@@", "version": "1.0.0"}`, ""},
+		{"invalid lockfile", `{"name": "app"}`, `{`},
+		{"unsupported lockfile version", `{"name": "app"}`, `{"lockfileVersion": 1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lockfile io.Reader
+			if tt.lockfile != "" {
+				lockfile = strings.NewReader(tt.lockfile)
+			}
+			if _, _, err := Read(strings.NewReader(tt.manifest), lockfile); err == nil {
+				t.Errorf("Read() error = nil, want error")
+			}
+		})
+	}
+}
+
+func Test_coordinate(t *testing.T) {
+	tests := []struct {
+		name          string
+		wantNamespace string
+		wantName      string
+	}{
+		{"lodash", "npm", "lodash"},
+		{"@angular/core", "angular", "core"},
+		{"@invalid", "npm", "@invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := coordinate(tt.name, "1.0.0")
+			if got.Namespace != tt.wantNamespace || got.Name != tt.wantName {
+				t.Errorf("coordinate() = %v/%v, want %v/%v", got.Namespace, got.Name, tt.wantNamespace, tt.wantName)
+			}
+		})
+	}
+}
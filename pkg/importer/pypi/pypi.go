@@ -0,0 +1,202 @@
+// Package pypi imports Python projects from requirements.txt files and pyproject.toml manifests.
+//
+// Project names are normalized according to PEP 503, which maps them onto lowercase alphanumeric characters,
+// '-' and '.', see https://peps.python.org/pep-0503/#normalized-names. Projects have no namespace and are
+// mapped onto the namespace DefaultNamespace. Only one version can be represented per dependency: exact
+// versions are kept, for version ranges the lower bound is used. Because dependencies cannot carry annotations,
+// the original names and requirements are kept as module annotations:
+//
+//	pypi.name                 the project name
+//	pypi.dependency.<i>       the requirement of the i-th dependency, e.g. "requests[socks]>=2.28; python_version>'3.8'"
+//	pypi.group.<i>            the comma-separated groups of the i-th dependency declared by pyproject.toml:
+//	                          main, extra.<name> for optional dependencies or group.<name> for Poetry groups
+package pypi
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+const (
+	// Type is the module type of Python projects.
+	Type = "pypi"
+	// DefaultNamespace is the namespace of all Python projects.
+	DefaultNamespace = "pypi"
+)
+
+var (
+	separators      = regexp.MustCompile(`[-_.]+`)
+	requirementName = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?`)
+)
+
+// specifierOperators is ordered, so that longer operators are matched first.
+var specifierOperators = []string{"===", "==", "!=", "~=", "<=", ">=", "<", ">", "^", "~"}
+
+// Normalize returns the normalized project name according to PEP 503,
+// e.g. Foo.Bar_baz becomes foo-bar-baz.
+func Normalize(name string) string {
+	return strings.ToLower(separators.ReplaceAllString(name, "-"))
+}
+
+// requirement is a dependency specification according to PEP 508, e.g. "requests[socks]>=2.28; python_version>'3.8'".
+type requirement struct {
+	text       string
+	name       string
+	specifiers []string
+	url        string
+}
+
+// parseRequirement parses the name, version specifiers and URL of the requirement.
+// Extras and environment markers are kept in the text only. It returns false if the text is no valid requirement.
+func parseRequirement(text string) (requirement, bool) {
+	text = strings.TrimSpace(text)
+	r := requirement{text: text}
+
+	spec := text
+	if i := strings.Index(spec, ";"); i >= 0 {
+		spec = spec[:i]
+	}
+
+	r.name = requirementName.FindString(spec)
+	if r.name == "" {
+		return requirement{}, false
+	}
+	spec = strings.TrimSpace(spec[len(r.name):])
+
+	if strings.HasPrefix(spec, "[") {
+		end := strings.Index(spec, "]")
+		if end < 0 {
+			return requirement{}, false
+		}
+		spec = strings.TrimSpace(spec[end+1:])
+	}
+
+	if strings.HasPrefix(spec, "@") {
+		r.url = strings.TrimSpace(spec[1:])
+		return r, true
+	}
+
+	if spec != "" && !strings.ContainsAny(spec[:1], "(=!~<>") {
+		return requirement{}, false
+	}
+	spec = strings.TrimSuffix(strings.TrimPrefix(spec, "("), ")")
+	r.specifiers = splitSpecifiers(spec)
+	return r, true
+}
+
+// splitSpecifiers splits comma-separated version specifiers and removes whitespace.
+func splitSpecifiers(spec string) []string {
+	var specifiers []string
+	for _, specifier := range strings.Split(spec, ",") {
+		if specifier = strings.Join(strings.Fields(specifier), ""); specifier != "" {
+			specifiers = append(specifiers, specifier)
+		}
+	}
+	return specifiers
+}
+
+// pickVersion returns the version to represent the specifiers by: the exact version, if any,
+// or otherwise the lower bound. It reports whether the version represents the specifiers exactly.
+func pickVersion(specifiers []string) (string, bool) {
+	var lowerBound string
+	for _, specifier := range specifiers {
+		operator := ""
+		for _, op := range specifierOperators {
+			if strings.HasPrefix(specifier, op) {
+				operator = op
+				break
+			}
+		}
+		version := strings.TrimPrefix(specifier, operator)
+
+		switch operator {
+		case "", "==", "===":
+			if version == "*" {
+				continue
+			}
+			if !strings.HasSuffix(version, ".*") && len(specifiers) == 1 {
+				return version, true
+			}
+			if lowerBound == "" {
+				lowerBound = strings.TrimSuffix(version, ".*")
+			}
+		case ">=", ">", "~=", "^", "~":
+			if lowerBound == "" {
+				lowerBound = version
+			}
+		}
+	}
+	return lowerBound, false
+}
+
+// moduleBuilder adds requirements as dependencies to a module.
+type moduleBuilder struct {
+	module  *v1.Module
+	report  *importer.Report
+	indexes map[string]int
+}
+
+func newModuleBuilder(name string, version string, report *importer.Report) (*moduleBuilder, bool) {
+	c, ok := report.Coordinate(name, "", coordinate(name, version))
+	if !ok {
+		return nil, false
+	}
+
+	module := c.Module()
+	module.Annotations = map[string]string{"pypi.name": name}
+	return &moduleBuilder{module: module, report: report, indexes: make(map[string]int)}, true
+}
+
+// add adds the requirement as dependency. A requirement of an already added project
+// only adds the group, whereas a deviating requirement is reported as dropped.
+func (b *moduleBuilder) add(r requirement, group string) {
+	name := Normalize(r.name)
+	if i, ok := b.indexes[name]; ok {
+		index := strconv.Itoa(i)
+		if group != "" {
+			b.module.Annotations["pypi.group."+index] += "," + group
+		}
+		if b.module.Annotations["pypi.dependency."+index] != r.text {
+			b.report.Drop(r.name, "dependency", r.text, "project already required by "+b.module.Annotations["pypi.dependency."+index])
+		}
+		return
+	}
+
+	version, exact := pickVersion(r.specifiers)
+	switch {
+	case r.url != "":
+		b.report.Drop(r.name, "url", r.url, "direct references cannot be represented")
+	case len(r.specifiers) > 0 && !exact && version != "":
+		b.report.Add(r.name, "version", strings.Join(r.specifiers, ","), version, "version ranges cannot be represented")
+	case len(r.specifiers) > 0 && !exact:
+		b.report.Drop(r.name, "version", strings.Join(r.specifiers, ","), "version ranges cannot be represented")
+	}
+
+	c, ok := b.report.Coordinate(r.name, "", coordinate(r.name, version))
+	if !ok {
+		b.report.Drop(r.name, "dependency", r.text, "no valid name can be derived")
+		return
+	}
+
+	index := strconv.Itoa(len(b.module.Dependencies))
+	b.indexes[name] = len(b.module.Dependencies)
+	b.module.Dependencies = append(b.module.Dependencies, c.Dependency())
+	b.module.Annotations["pypi.dependency."+index] = b.report.NormalizeAnnotationValue(r.name, "annotations.pypi.dependency", r.text)
+	if group != "" {
+		b.module.Annotations["pypi.group."+index] = group
+	}
+}
+
+// coordinate maps the project name onto namespace and the PEP 503 normalized name.
+func coordinate(name string, version string) v1.Coordinate {
+	return v1.Coordinate{
+		Namespace: DefaultNamespace,
+		Name:      Normalize(name),
+		Type:      Type,
+		Version:   version,
+	}
+}
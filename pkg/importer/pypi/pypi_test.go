@@ -0,0 +1,84 @@
+package pypi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"requests", "requests"},
+		{"Django", "django"},
+		{"zope.interface", "zope-interface"},
+		{"typing_extensions", "typing-extensions"},
+		{"Foo.-_Bar", "foo-bar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.name); got != tt.want {
+				t.Errorf("Normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseRequirement(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   requirement
+		wantOK bool
+	}{
+		{"requests", requirement{text: "requests", name: "requests"}, true},
+		{"requests==2.31.0", requirement{text: "requests==2.31.0", name: "requests", specifiers: []string{"==2.31.0"}}, true},
+		{"requests[socks] >= 2.28, < 3 ; python_version > '3.8'", requirement{
+			text:       "requests[socks] >= 2.28, < 3 ; python_version > '3.8'",
+			name:       "requests",
+			specifiers: []string{">=2.28", "<3"},
+		}, true},
+		{"name (>=1.0)", requirement{text: "name (>=1.0)", name: "name", specifiers: []string{">=1.0"}}, true},
+		{"pip @ https://example.com/pip.zip", requirement{text: "pip @ https://example.com/pip.zip", name: "pip", url: "https://example.com/pip.zip"}, true},
+		{"./local/package", requirement{}, false},
+		{"git+https://github.com/acme/app.git", requirement{}, false},
+		{"name[unterminated", requirement{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := parseRequirement(tt.text)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRequirement() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_pickVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		specifiers  []string
+		wantVersion string
+		wantExact   bool
+	}{
+		{"none", nil, "", false},
+		{"exact", []string{"==1.0"}, "1.0", true},
+		{"arbitrary equality", []string{"===1.0"}, "1.0", true},
+		{"bare version", []string{"1.0"}, "1.0", true},
+		{"wildcard", []string{"==1.*"}, "1", false},
+		{"any", []string{"*"}, "", false},
+		{"lower bound", []string{"<2", ">=1.5"}, "1.5", false},
+		{"compatible release", []string{"~=1.4.2"}, "1.4.2", false},
+		{"caret", []string{"^1.2"}, "1.2", false},
+		{"upper bound only", []string{"<2"}, "", false},
+		{"exclusion", []string{"!=1.1"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, exact := pickVersion(tt.specifiers)
+			if version != tt.wantVersion || exact != tt.wantExact {
+				t.Errorf("pickVersion() = %v, %v, want %v, %v", version, exact, tt.wantVersion, tt.wantExact)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package pypi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+	"github.com/opendependency/go-spec/pkg/importer/internal/toml"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Dependency groups as recorded in the pypi.group.<i> annotations.
+const (
+	GroupMain        = "main"
+	GroupExtraPrefix = "extra."
+	GroupPrefix      = "group."
+)
+
+// ReadPyProject parses the pyproject.toml manifest and converts it into a module.
+//
+// The project metadata is read from the [project] table according to PEP 621 or alternatively
+// from the [tool.poetry] table. Dependencies, optional dependencies and Poetry dependency groups
+// become UPSTREAM dependencies. The Python version requirement of Poetry is ignored. Identifiers
+// are normalized to satisfy the specification constraints and all lossy transformations are recorded
+// in the returned report.
+func ReadPyProject(r io.Reader) (*v1.Module, *importer.Report, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pyproject.toml: %w", err)
+	}
+	document, err := toml.Parse(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pyproject.toml: %w", err)
+	}
+
+	project := toml.Table(document, "project")
+	poetry := toml.Table(document, "tool", "poetry")
+
+	name, version := toml.String(project, "name"), toml.String(project, "version")
+	if name == "" {
+		name = toml.String(poetry, "name")
+	}
+	if version == "" {
+		version = toml.String(poetry, "version")
+	}
+	if name == "" {
+		return nil, nil, errors.New("pyproject.toml: project.name or tool.poetry.name must be set")
+	}
+
+	report := &importer.Report{}
+	b, ok := newModuleBuilder(name, version, report)
+	if !ok {
+		return nil, nil, fmt.Errorf("pyproject.toml: name %q: no valid name can be derived", name)
+	}
+
+	for _, text := range toml.Strings(project, "dependencies") {
+		addPEP508(b, text, GroupMain)
+	}
+	extras := toml.Table(project, "optional-dependencies")
+	for _, extra := range sortedKeys(extras) {
+		for _, text := range toml.Strings(extras, extra) {
+			addPEP508(b, text, GroupExtraPrefix+extra)
+		}
+	}
+
+	addPoetry(b, toml.Table(poetry, "dependencies"), GroupMain)
+	addPoetry(b, toml.Table(poetry, "dev-dependencies"), GroupPrefix+"dev")
+	groups := toml.Table(poetry, "group")
+	for _, group := range sortedKeys(groups) {
+		addPoetry(b, toml.Table(groups, group, "dependencies"), GroupPrefix+group)
+	}
+
+	return b.module, report, nil
+}
+
+func addPEP508(b *moduleBuilder, text string, group string) {
+	r, ok := parseRequirement(text)
+	if !ok {
+		b.report.Drop("pyproject.toml", "requirement", text, "invalid requirement")
+		return
+	}
+	b.add(r, group)
+}
+
+// addPoetry adds the Poetry dependencies, which map names to version constraints like "^1.2" or
+// to tables like { version = "^1.2", extras = ["socks"] }.
+func addPoetry(b *moduleBuilder, dependencies map[string]interface{}, group string) {
+	for _, name := range sortedKeys(dependencies) {
+		if name == "python" {
+			continue
+		}
+
+		r := requirement{name: name}
+		var constraint string
+		switch declaration := dependencies[name].(type) {
+		case string:
+			constraint = declaration
+		case map[string]interface{}:
+			constraint = toml.String(declaration, "version")
+			for _, key := range []string{"git", "path", "url"} {
+				if location := toml.String(declaration, key); location != "" {
+					r.url = location
+				}
+			}
+		default:
+			b.report.Drop("pyproject.toml", "dependency", name, "unsupported declaration")
+			continue
+		}
+
+		r.text = strings.TrimSpace(name + " " + constraint)
+		if constraint != "*" {
+			r.specifiers = splitSpecifiers(constraint)
+		}
+		b.add(r, group)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,137 @@
+package pypi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadPyProject(t *testing.T) {
+	tests := []struct {
+		name             string
+		pyproject        string
+		wantModule       string
+		wantDependencies []string
+		wantAnnotations  map[string]string
+		wantLosses       int
+	}{
+		{
+			name: "PEP 621",
+			pyproject: `[project]
+name = "acme.app"
+version = "2.0.0"
+dependencies = [
+  "requests==2.31.0",
+  "click>=8",
+]
+
+[project.optional-dependencies]
+test = ["pytest==7.4.2", "requests==2.31.0"]
+`,
+			wantModule: "pypi/acme-app:pypi@2.0.0",
+			wantDependencies: []string{
+				"pypi/requests:pypi@2.31.0",
+				"pypi/click:pypi@8",
+				"pypi/pytest:pypi@7.4.2",
+			},
+			wantAnnotations: map[string]string{
+				"pypi.name":         "acme.app",
+				"pypi.dependency.0": "requests==2.31.0",
+				"pypi.group.0":      "main,extra.test",
+				"pypi.dependency.1": "click>=8",
+				"pypi.group.1":      "main",
+				"pypi.dependency.2": "pytest==7.4.2",
+				"pypi.group.2":      "extra.test",
+			},
+			wantLosses: 1,
+		},
+		{
+			name: "Poetry",
+			pyproject: `[tool.poetry]
+name = "poetry-app"
+version = "0.1.0"
+
+[tool.poetry.dependencies]
+python = "^3.9"
+requests = { version = "^2.28", extras = ["socks"] }
+local = { path = "../local" }
+pendulum = "2.1.2"
+
+[tool.poetry.group.test.dependencies]
+pytest = "*"
+
+[tool.poetry.dev-dependencies]
+black = "23.9.1"
+`,
+			wantModule: "pypi/poetry-app:pypi@0.1.0",
+			wantDependencies: []string{
+				"pypi/local:pypi@unknown",
+				"pypi/pendulum:pypi@2.1.2",
+				"pypi/requests:pypi@2.28",
+				"pypi/black:pypi@23.9.1",
+				"pypi/pytest:pypi@unknown",
+			},
+			wantAnnotations: map[string]string{
+				"pypi.name":         "poetry-app",
+				"pypi.dependency.0": "local",
+				"pypi.group.0":      "main",
+				"pypi.dependency.1": "pendulum 2.1.2",
+				"pypi.group.1":      "main",
+				"pypi.dependency.2": "requests ^2.28",
+				"pypi.group.2":      "main",
+				"pypi.dependency.3": "black 23.9.1",
+				"pypi.group.3":      "group.dev",
+				"pypi.dependency.4": "pytest *",
+				"pypi.group.4":      "group.test",
+			},
+			wantLosses: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			module, report, err := ReadPyProject(strings.NewReader(tt.pyproject))
+			if err != nil {
+				t.Fatalf("ReadPyProject() error = %v", err)
+			}
+
+			if got := module.Coordinate().String(); got != tt.wantModule {
+				t.Errorf("ReadPyProject() = %v, want %v", got, tt.wantModule)
+			}
+			if err := module.ValidateAll(); err != nil {
+				t.Errorf("ReadPyProject() module is invalid: %v", err)
+			}
+
+			var dependencies []string
+			for _, d := range module.Dependencies {
+				dependencies = append(dependencies, d.Coordinate().String())
+			}
+			if !reflect.DeepEqual(dependencies, tt.wantDependencies) {
+				t.Errorf("ReadPyProject() dependencies = %v, want %v", dependencies, tt.wantDependencies)
+			}
+			if !reflect.DeepEqual(module.Annotations, tt.wantAnnotations) {
+				t.Errorf("ReadPyProject() annotations = %v, want %v", module.Annotations, tt.wantAnnotations)
+			}
+			if len(report.Losses) != tt.wantLosses {
+				t.Errorf("ReadPyProject() report =\n%s\nwant %d losses", report, tt.wantLosses)
+			}
+		})
+	}
+}
+
+func TestReadPyProject_errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		pyproject string
+	}{
+		{"invalid TOML", "[project"},
+		{"missing name", "[project]\nversion = \"1.0\""},
+		{"invalid name", "[project]\nname = \"___\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ReadPyProject(strings.NewReader(tt.pyproject)); err == nil {
+				t.Errorf("ReadPyProject() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package pypi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// ReadRequirements parses the requirements.txt file and converts it into a module with the given name and version,
+// because the file does not declare the project itself. All requirements become UPSTREAM dependencies.
+// Options like --index-url and requirements without project name like local paths cannot be represented
+// and are reported as dropped together with all other lossy transformations.
+func ReadRequirements(r io.Reader, name string, version string) (*v1.Module, *importer.Report, error) {
+	report := &importer.Report{}
+	b, ok := newModuleBuilder(name, version, report)
+	if !ok {
+		return nil, nil, fmt.Errorf("requirements.txt: name %q: no valid name can be derived", name)
+	}
+
+	scanner := bufio.NewScanner(r)
+	var logical string
+	start := 0
+	for number := 1; scanner.Scan(); number++ {
+		line := scanner.Text()
+		if logical == "" {
+			start = number
+		}
+
+		// a backslash at the end of the line continues the line
+		if strings.HasSuffix(line, "\\") {
+			logical += strings.TrimSuffix(line, "\\") + " "
+			continue
+		}
+		logical += line
+
+		addRequirementLine(b, fmt.Sprintf("requirements.txt:%d", start), logical)
+		logical = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("requirements.txt: %w", err)
+	}
+	if logical != "" {
+		addRequirementLine(b, fmt.Sprintf("requirements.txt:%d", start), logical)
+	}
+
+	return b.module, report, nil
+}
+
+func addRequirementLine(b *moduleBuilder, source string, line string) {
+	line = strings.TrimSpace(stripComment(line))
+	if line == "" {
+		return
+	}
+
+	if strings.HasPrefix(line, "-") {
+		b.report.Drop(source, "option", line, "options cannot be represented")
+		return
+	}
+
+	// per-requirement options like --hash follow the requirement
+	if i := strings.Index(line, " --"); i >= 0 {
+		b.report.Drop(source, "option", strings.TrimSpace(line[i:]), "options cannot be represented")
+		line = strings.TrimSpace(line[:i])
+	}
+
+	r, ok := parseRequirement(line)
+	if !ok {
+		b.report.Drop(source, "requirement", line, "requirements without project name cannot be represented")
+		return
+	}
+	b.add(r, "")
+}
+
+// stripComment removes a comment starting with # at the beginning of the line or after whitespace.
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,79 @@
+package pypi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const requirementsTxt = `# production requirements
+--index-url https://pypi.org/simple
+
+Django==4.2.5
+requests[socks]>=2.28,<3 ; python_version > "3.8"  # HTTP client
+zope.interface==6.0 \
+    --hash=sha256:abc
+typing_extensions
+numpy==1.26.0; python_version >= "3.9"
+numpy==1.24.4; python_version < "3.9"
+-e ./local/package
+./other/package
+`
+
+func TestReadRequirements(t *testing.T) {
+	module, report, err := ReadRequirements(strings.NewReader(requirementsTxt), "My_Service", "1.0.0")
+	if err != nil {
+		t.Fatalf("ReadRequirements() error = %v", err)
+	}
+
+	if got := module.Coordinate().String(); got != "pypi/my-service:pypi@1.0.0" {
+		t.Errorf("ReadRequirements() = %v, want pypi/my-service:pypi@1.0.0", got)
+	}
+	if err := module.ValidateAll(); err != nil {
+		t.Errorf("ReadRequirements() module is invalid: %v", err)
+	}
+
+	var dependencies []string
+	for _, d := range module.Dependencies {
+		dependencies = append(dependencies, d.Coordinate().String())
+	}
+	wantDependencies := []string{
+		"pypi/django:pypi@4.2.5",
+		"pypi/requests:pypi@2.28",
+		"pypi/zope-interface:pypi@6.0",
+		"pypi/typing-extensions:pypi@unknown",
+		"pypi/numpy:pypi@1.26.0",
+	}
+	if !reflect.DeepEqual(dependencies, wantDependencies) {
+		t.Errorf("ReadRequirements() dependencies = %v, want %v", dependencies, wantDependencies)
+	}
+
+	wantAnnotations := map[string]string{
+		"pypi.name":         "My_Service",
+		"pypi.dependency.0": "Django==4.2.5",
+		"pypi.dependency.1": `requests[socks]>=2.28,<3 ; python_version > "3.8"`,
+		"pypi.dependency.2": "zope.interface==6.0",
+		"pypi.dependency.3": "typing_extensions",
+		"pypi.dependency.4": `numpy==1.26.0; python_version >= "3.9"`,
+	}
+	if !reflect.DeepEqual(module.Annotations, wantAnnotations) {
+		t.Errorf("ReadRequirements() annotations = %v, want %v", module.Annotations, wantAnnotations)
+	}
+
+	wantReport := `requirements.txt:2: option "--index-url https://pypi.org/simple" dropped: options cannot be represented
+requests: version ">=2.28,<3" changed to "2.28": version ranges cannot be represented
+requirements.txt:6: option "--hash=sha256:abc" dropped: options cannot be represented
+typing_extensions: version "" changed to "unknown": must be set
+numpy: dependency "numpy==1.24.4; python_version < \"3.9\"" dropped: project already required by numpy==1.26.0; python_version >= "3.9"
+requirements.txt:11: option "-e ./local/package" dropped: options cannot be represented
+requirements.txt:12: requirement "./other/package" dropped: requirements without project name cannot be represented`
+	if got := report.String(); got != wantReport {
+		t.Errorf("ReadRequirements() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func TestReadRequirements_invalidName(t *testing.T) {
+	if _, _, err := ReadRequirements(strings.NewReader(""), "___", "1.0.0"); err == nil {
+		t.Errorf("ReadRequirements() error = nil, want error")
+	}
+}
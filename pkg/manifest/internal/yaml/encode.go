@@ -0,0 +1,215 @@
+package yaml
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// indentWidth is the number of spaces per nesting level.
+const indentWidth = 2
+
+// isNumber matches scalars, which other YAML tools would resolve to integers or floats.
+var isNumber = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9][0-9_]*(\.[0-9_]*)?)([eE][-+]?[0-9]+)?$|^0x[0-9a-fA-F_]+$|^0o?[0-7_]+$|^0b[01_]+$|^[-+]?\.(inf|Inf|INF)$|^\.(nan|NaN|NAN)$`).MatchString
+
+// Encode encodes the documents in block style with their comments.
+// Scalars are written plain if possible, multi-line scalars as literal block scalars and
+// other scalars quoted. Empty collections are written in flow style.
+func Encode(docs []*Document) []byte {
+	var e encoder
+	for i, doc := range docs {
+		if i > 0 {
+			e.b.WriteString("---\n")
+		}
+		if doc.Root != nil {
+			e.root(doc.Root)
+		}
+		e.comments(doc.FootComment, 0)
+	}
+	return []byte(e.b.String())
+}
+
+type encoder struct {
+	b strings.Builder
+}
+
+func (e *encoder) root(n *Node) {
+	switch {
+	case n.Kind == MappingNode && len(n.Content) > 0:
+		e.mapping(n, 0, false)
+	case n.Kind == SequenceNode && len(n.Content) > 0:
+		e.sequence(n, 0)
+	default:
+		e.b.WriteString(strings.TrimPrefix(e.inline(n, 0, n.LineComment), " "))
+	}
+}
+
+// mapping writes the entries of the mapping at the indentation.
+// If inline is set, the first key is written on the current line.
+func (e *encoder) mapping(n *Node, indent int, inline bool) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if i > 0 || !inline {
+			e.comments(key.HeadComment, indent)
+			e.indent(indent)
+		}
+		e.b.WriteString(scalar(key.Value))
+		e.b.WriteByte(':')
+		e.value(value, indent, key.LineComment)
+	}
+}
+
+// sequence writes the items of the sequence at the indentation.
+func (e *encoder) sequence(n *Node, indent int) {
+	for _, item := range n.Content {
+		e.comments(item.HeadComment, indent)
+		e.indent(indent)
+		e.b.WriteByte('-')
+
+		if item.Kind == MappingNode && len(item.Content) > 0 && item.LineComment == "" && len(item.Content[0].HeadComment) == 0 {
+			e.b.WriteByte(' ')
+			e.mapping(item, indent+indentWidth, true)
+			continue
+		}
+		e.value(item, indent, item.LineComment)
+	}
+}
+
+// value writes the value following a mapping key or sequence entry indicator, whose line has the indentation.
+func (e *encoder) value(n *Node, indent int, comment string) {
+	switch {
+	case n.Kind == MappingNode && len(n.Content) > 0:
+		e.lineComment(comment)
+		e.b.WriteByte('\n')
+		e.mapping(n, indent+indentWidth, false)
+	case n.Kind == SequenceNode && len(n.Content) > 0:
+		e.lineComment(comment)
+		e.b.WriteByte('\n')
+		e.sequence(n, indent+indentWidth)
+	default:
+		e.b.WriteString(e.inline(n, indent, comment))
+	}
+}
+
+// inline returns a scalar or an empty collection followed by the comment and the line end.
+// Literal block scalars include their lines at the indentation of the next level.
+func (e *encoder) inline(n *Node, indent int, comment string) string {
+	var b strings.Builder
+	switch {
+	case n.Kind == MappingNode:
+		b.WriteString(" {}")
+	case n.Kind == SequenceNode:
+		b.WriteString(" []")
+	case n.IsNull():
+		if n.Value != "" {
+			b.WriteString(" " + n.Value)
+		}
+	case isLiteral(n.Value):
+		b.WriteString(" |")
+		switch trailing := len(n.Value) - len(strings.TrimRight(n.Value, "\n")); {
+		case trailing == 0:
+			b.WriteByte('-')
+		case trailing > 1:
+			b.WriteByte('+')
+		}
+		if comment != "" {
+			b.WriteString(" " + comment)
+		}
+		b.WriteByte('\n')
+
+		lines := strings.Split(n.Value, "\n")
+		if strings.HasSuffix(n.Value, "\n") {
+			lines = lines[:len(lines)-1]
+		}
+		for _, line := range lines {
+			if line != "" {
+				b.WriteString(strings.Repeat(" ", indent+indentWidth))
+				b.WriteString(line)
+			}
+			b.WriteByte('\n')
+		}
+		return b.String()
+	default:
+		b.WriteString(" " + scalar(n.Value))
+	}
+
+	if comment != "" {
+		b.WriteString(" " + comment)
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
+
+func (e *encoder) lineComment(comment string) {
+	if comment != "" {
+		e.b.WriteString(" " + comment)
+	}
+}
+
+// comments writes the comment lines at the indentation, whereas empty strings become empty lines.
+func (e *encoder) comments(lines []string, indent int) {
+	for _, line := range lines {
+		if line != "" {
+			e.indent(indent)
+			e.b.WriteString(line)
+		}
+		e.b.WriteByte('\n')
+	}
+}
+
+func (e *encoder) indent(indent int) {
+	e.b.WriteString(strings.Repeat(" ", indent))
+}
+
+// scalar returns the scalar in plain style if it is read back as the same string, otherwise quoted.
+func scalar(s string) string {
+	if isPlain(s) {
+		return s
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// isPlain reports whether the scalar can be written in plain style without changing its value or type.
+func isPlain(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) || strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return false
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	switch strings.ToLower(s) {
+	case "~", "null", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return false
+	}
+	return !isNumber(s)
+}
+
+// isLiteral reports whether the multi-line scalar can be written as literal block scalar.
+func isLiteral(s string) bool {
+	if !strings.Contains(strings.TrimRight(s, "\n"), "\n") || s[0] == ' ' || s[0] == '\t' || s[0] == '\n' {
+		return false
+	}
+	for _, line := range strings.Split(s, "\n") {
+		if line != strings.TrimRight(line, " \t") {
+			return false
+		}
+		for _, r := range line {
+			if !unicode.IsPrint(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
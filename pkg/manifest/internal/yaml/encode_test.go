@@ -0,0 +1,166 @@
+package yaml
+
+import (
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	data := `# head of a
+
+a:   1 # line of a
+b: # line of b
+  # head of c
+  c: 'it''s'
+  d: [x, "y"]
+  e: {}
+list:
+- name: x
+  type: 'y'
+-   # line of item
+    name: z
+- - nested
+-
+  # head of key
+  key: value
+text: |
+  line 1
+  line 2
+folded: >-
+  a
+  b
+empty:
+# foot
+---
+- single
+`
+	want := `# head of a
+
+a: '1' # line of a
+b: # line of b
+  # head of c
+  c: it's
+  d:
+    - x
+    - 'y'
+  e: {}
+list:
+  - name: x
+    type: 'y'
+  - # line of item
+    name: z
+  -
+    - nested
+  -
+    # head of key
+    key: value
+text: |
+  line 1
+  line 2
+folded: a b
+empty:
+# foot
+---
+- single
+`
+
+	docs, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got := string(Encode(docs))
+	if got != want {
+		t.Errorf("Encode() = %s, want %s", got, want)
+	}
+
+	reparsed, err := Parse([]byte(got))
+	if err != nil {
+		t.Fatalf("Parse() of encoded documents error = %v", err)
+	}
+	if again := string(Encode(reparsed)); again != got {
+		t.Errorf("Encode() is not stable, got %s, want %s", again, got)
+	}
+}
+
+func Test_scalar(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain", "plain"},
+		{"v1.0.0", "v1.0.0"},
+		{"1.0.0", "1.0.0"},
+		{"http://example.com", "http://example.com"},
+		{"", "''"},
+		{"1.0", "'1.0'"},
+		{"42", "'42'"},
+		{"0x1F", "'0x1F'"},
+		{"true", "'true'"},
+		{"No", "'No'"},
+		{"null", "'null'"},
+		{"~", "'~'"},
+		{"-x", "'-x'"},
+		{"a: b", "'a: b'"},
+		{"a #b", "'a #b'"},
+		{"a:", "'a:'"},
+		{" padded", "' padded'"},
+		{"it's", "it's"},
+		{"'quoted'", "'''quoted'''"},
+		{"tab\there", `"tab\there"`},
+		{"line\n", `"line\n"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got := scalar(tt.value)
+			if got != tt.want {
+				t.Errorf("scalar() = %v, want %v", got, tt.want)
+			}
+
+			docs, err := Parse([]byte("key: " + got))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if value := docs[0].Root.Content[1].Value; value != tt.value {
+				t.Errorf("Parse() = %q, want %q", value, tt.value)
+			}
+		})
+	}
+}
+
+func Test_isLiteral(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"a\nb", true},
+		{"a\nb\n", true},
+		{"a\n\nb\n\n", true},
+		{"a\n", false},
+		{"a", false},
+		{" a\nb", false},
+		{"\na\nb", false},
+		{"a \nb", false},
+		{"a\tb\nc", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := isLiteral(tt.value); got != tt.want {
+				t.Errorf("isLiteral() = %v, want %v", got, tt.want)
+			}
+
+			if !tt.want {
+				return
+			}
+			encoded := Encode([]*Document{{Root: &Node{Kind: MappingNode, Content: []*Node{
+				{Kind: ScalarNode, Value: "key"},
+				{Kind: ScalarNode, Value: tt.value},
+			}}}})
+			docs, err := Parse(encoded)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if value := docs[0].Root.Content[1].Value; value != tt.value {
+				t.Errorf("Parse() of %s = %q, want %q", encoded, value, tt.value)
+			}
+		})
+	}
+}
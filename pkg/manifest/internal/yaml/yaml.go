@@ -0,0 +1,1011 @@
+// Package yaml is a minimal YAML reader and writer sufficient for hand-written manifests,
+// see https://yaml.org/spec/1.2.2/.
+//
+// Documents are decoded into a node tree, which keeps the comments and the positions of the nodes.
+// Block mappings and sequences, flow collections on a single line, plain scalars, single and double
+// quoted scalars on a single line as well as literal and folded block scalars are supported.
+// Anchors, aliases, tags, directives and complex keys are not supported. Collections must not be
+// nested deeper than 10000 levels.
+package yaml
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind is the kind of a node.
+type Kind int
+
+const (
+	// ScalarNode is a string value.
+	ScalarNode Kind = iota + 1
+	// MappingNode is a collection of key-value pairs.
+	MappingNode
+	// SequenceNode is an ordered collection of values.
+	SequenceNode
+)
+
+// Style is the presentation style of a node.
+type Style int
+
+const (
+	// PlainStyle is an unquoted scalar or a block collection.
+	PlainStyle Style = iota
+	// SingleQuotedStyle is a scalar in single quotes.
+	SingleQuotedStyle
+	// DoubleQuotedStyle is a scalar in double quotes, which may contain escape sequences.
+	DoubleQuotedStyle
+	// LiteralStyle is a block scalar introduced by |, which keeps line breaks.
+	LiteralStyle
+	// FoldedStyle is a block scalar introduced by >, which folds line breaks into spaces.
+	FoldedStyle
+	// FlowStyle is a collection in brackets or braces.
+	FlowStyle
+)
+
+// Node is a node of the document tree.
+type Node struct {
+	Kind  Kind
+	Style Style
+	// Value is the value of a scalar.
+	Value string
+	// Content contains the items of a sequence or the alternating keys and values of a mapping.
+	Content []*Node
+
+	// Line and Column are the 1-based position of the node.
+	Line   int
+	Column int
+
+	// HeadComment contains the comment lines preceding a mapping key or a sequence item.
+	// Empty lines separating entries are kept as empty strings.
+	HeadComment []string
+	// LineComment is the comment following a mapping key or a sequence item on the same line.
+	LineComment string
+}
+
+// IsNull reports whether the node is a plain scalar denoting null, e.g. an empty value.
+func (n *Node) IsNull() bool {
+	if n == nil {
+		return true
+	}
+	if n.Kind != ScalarNode || n.Style != PlainStyle {
+		return false
+	}
+	switch n.Value {
+	case "", "~", "null", "Null", "NULL":
+		return true
+	default:
+		return false
+	}
+}
+
+// Document is a document of a YAML stream.
+type Document struct {
+	// Root is the root node or nil if the document is empty.
+	Root *Node
+	// FootComment contains the comment lines following the root node.
+	FootComment []string
+}
+
+// Error reports a syntax error at a position in the stream.
+type Error struct {
+	// Line and Column are the 1-based position of the error.
+	Line   int
+	Column int
+	// Message describes the error.
+	Message string
+}
+
+// Error returns the position followed by the message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("yaml: line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// Parse decodes all documents of the YAML stream.
+func Parse(data []byte) ([]*Document, error) {
+	s := strings.TrimPrefix(string(data), "\ufeff")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	p := &parser{lines: strings.Split(strings.TrimSuffix(s, "\n"), "\n")}
+	return p.stream()
+}
+
+type parser struct {
+	lines []string
+	// n is the index of the current line.
+	n int
+	// start is the byte offset of the unconsumed content of the current line.
+	start int
+	// pending contains the comment lines not yet attached to a node.
+	pending []string
+	// depth is the number of collections enclosing the current position.
+	depth int
+	// columnLine, columnOff and columnRunes cache the line, byte offset and rune count of the last
+	// computed column, so that the columns of a line are counted incrementally.
+	columnLine, columnOff, columnRunes int
+}
+
+// maxDepth is the maximum nesting depth of collections.
+const maxDepth = 10000
+
+func (p *parser) stream() ([]*Document, error) {
+	var docs []*Document
+	// explicit reports whether the current document was started by --- and has no content yet.
+	explicit := false
+
+	for {
+		p.skipEmpty()
+		if p.eof() {
+			break
+		}
+
+		line := p.lines[p.n]
+		switch {
+		case isMarker(line, "---"):
+			if explicit {
+				docs = append(docs, &Document{FootComment: p.takeFoot()})
+			}
+			explicit = true
+			if err := p.marker(); err != nil {
+				return nil, err
+			}
+			continue
+		case isMarker(line, "..."):
+			if explicit {
+				docs = append(docs, &Document{FootComment: p.takeFoot()})
+				explicit = false
+			}
+			if err := p.marker(); err != nil {
+				return nil, err
+			}
+			continue
+		case strings.HasPrefix(line, "%"):
+			return nil, p.errorf(0, "directives are not supported")
+		}
+
+		for len(p.pending) > 0 && p.pending[0] == "" {
+			p.pending = p.pending[1:]
+		}
+		indent, err := p.indent()
+		if err != nil {
+			return nil, err
+		}
+		p.start = indent
+		root, comment, err := p.node(-1)
+		if err != nil {
+			return nil, err
+		}
+		if comment != "" {
+			root.LineComment = comment
+		}
+
+		p.skipEmpty()
+		if !p.eof() && !isMarker(p.lines[p.n], "---") && !isMarker(p.lines[p.n], "...") {
+			return nil, p.errorf(indentation(p.lines[p.n]), "unexpected content after the document root")
+		}
+		docs = append(docs, &Document{Root: root, FootComment: p.takeFoot()})
+		explicit = false
+	}
+
+	if foot := p.takeFoot(); explicit || (len(docs) == 0 && len(foot) > 0) {
+		docs = append(docs, &Document{FootComment: foot})
+	}
+
+	return docs, nil
+}
+
+// marker consumes a document marker line, which may be followed by a comment.
+func (p *parser) marker() error {
+	line := p.lines[p.n]
+	rest := skipSpace(line, 3)
+	if rest < len(line) {
+		if line[rest] != '#' {
+			return p.errorf(rest, "content on a document marker line is not supported")
+		}
+		p.pending = append(p.pending, strings.TrimRight(line[rest:], " \t"))
+	}
+	p.n++
+	return nil
+}
+
+// node parses the node starting at the current position, whose parent node has the given indentation.
+// It returns the comment following a scalar on the same line.
+func (p *parser) node(parent int) (*Node, string, error) {
+	line := p.lines[p.n]
+	if isSequenceEntry(line, p.start) {
+		node, err := p.sequence(p.start)
+		return node, "", err
+	}
+
+	_, _, ok, err := p.key(p.start)
+	if err != nil {
+		return nil, "", err
+	}
+	if ok {
+		node, err := p.mapping(p.start)
+		return node, "", err
+	}
+
+	return p.scalar(parent, p.start)
+}
+
+// mapping parses a block mapping whose keys start at the given column.
+func (p *parser) mapping(column int) (*Node, error) {
+	if err := p.enter(column); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	node := &Node{Kind: MappingNode, Line: p.n + 1, Column: p.column(column)}
+	seen := make(map[string]bool)
+
+	for first := true; ; first = false {
+		if !first {
+			p.skipEmpty()
+			if p.eof() || p.isDocumentBoundary() {
+				break
+			}
+			indent, err := p.indent()
+			if err != nil {
+				return nil, err
+			}
+			if indent < column {
+				break
+			}
+			if indent > column {
+				return nil, p.errorf(indent, "unexpected indentation")
+			}
+			p.start = indent
+		}
+
+		key, next, ok, err := p.key(p.start)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, p.errorf(p.start, "expected a mapping key")
+		}
+		if seen[key.Value] {
+			return nil, p.errorf(p.start, "duplicate key %q", key.Value)
+		}
+		seen[key.Value] = true
+		key.HeadComment = p.takePending()
+
+		value, comment, err := p.value(column, next, true)
+		if err != nil {
+			return nil, err
+		}
+		key.LineComment = comment
+		node.Content = append(node.Content, key, value)
+	}
+
+	return node, nil
+}
+
+// sequence parses a block sequence whose entries start at the given column.
+func (p *parser) sequence(column int) (*Node, error) {
+	if err := p.enter(column); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	node := &Node{Kind: SequenceNode, Line: p.n + 1, Column: p.column(column)}
+
+	for first := true; ; first = false {
+		if !first {
+			p.skipEmpty()
+			if p.eof() || p.isDocumentBoundary() {
+				break
+			}
+			indent, err := p.indent()
+			if err != nil {
+				return nil, err
+			}
+			if indent < column {
+				break
+			}
+			if indent > column {
+				return nil, p.errorf(indent, "unexpected indentation")
+			}
+			if !isSequenceEntry(p.lines[p.n], indent) {
+				break
+			}
+			p.start = indent
+		}
+
+		head := p.takePending()
+		item, comment, err := p.value(column, p.start+1, false)
+		if err != nil {
+			return nil, err
+		}
+		item.HeadComment = head
+		if comment != "" {
+			item.LineComment = comment
+		}
+		node.Content = append(node.Content, item)
+	}
+
+	return node, nil
+}
+
+// value parses the value following a mapping key or a sequence entry indicator at offset off of the current line.
+// It returns the comment following the value on the same line.
+func (p *parser) value(parent int, off int, inMapping bool) (*Node, string, error) {
+	line := p.lines[p.n]
+	i := skipSpace(line, off)
+
+	if i == len(line) || line[i] == '#' {
+		var comment string
+		if i < len(line) {
+			comment = strings.TrimRight(line[i:], " \t")
+		}
+		null := &Node{Kind: ScalarNode, Line: p.n + 1, Column: p.column(off)}
+		p.n++
+
+		p.skipEmpty()
+		if p.eof() || p.isDocumentBoundary() {
+			return null, comment, nil
+		}
+		indent, err := p.indent()
+		if err != nil {
+			return nil, "", err
+		}
+		switch {
+		case indent > parent:
+			p.start = indent
+			node, scalarComment, err := p.node(parent)
+			if comment == "" {
+				comment = scalarComment
+			}
+			return node, comment, err
+		case inMapping && indent == parent && isSequenceEntry(p.lines[p.n], indent):
+			p.start = indent
+			node, err := p.sequence(indent)
+			return node, comment, err
+		default:
+			return null, comment, nil
+		}
+	}
+
+	p.start = i
+	if !inMapping {
+		return p.node(parent)
+	}
+
+	if isSequenceEntry(line, i) {
+		return nil, "", p.errorf(i, "block sequence must start on a new line")
+	}
+	if _, _, ok, err := p.key(i); err != nil || ok {
+		if err == nil {
+			err = p.errorf(i, "mapping values are not allowed here")
+		}
+		return nil, "", err
+	}
+
+	return p.scalar(parent, i)
+}
+
+// key parses the mapping key at offset off of the current line.
+// It returns the key and the offset following the ':' indicator, or false if there is no key.
+func (p *parser) key(off int) (*Node, int, bool, error) {
+	line := p.lines[p.n]
+	node := &Node{Kind: ScalarNode, Line: p.n + 1, Column: p.column(off)}
+
+	switch c := line[off]; c {
+	case '"', '\'':
+		value, end, err := p.quoted(line, off)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		end = skipSpace(line, end)
+		if !isMappingIndicator(line, end) {
+			return nil, 0, false, nil
+		}
+		node.Value = value
+		node.Style = SingleQuotedStyle
+		if c == '"' {
+			node.Style = DoubleQuotedStyle
+		}
+		return node, end + 1, true, nil
+	case '?':
+		if isSpaceAt(line, off+1) {
+			return nil, 0, false, p.errorf(off, "complex mapping keys are not supported")
+		}
+	case '-':
+		if isSpaceAt(line, off+1) {
+			return nil, 0, false, nil
+		}
+	case '[', '{', '#', '|', '>', '&', '*', '!', '%', '@', '`', ',', ']', '}':
+		return nil, 0, false, nil
+	}
+
+	for i := off; i < len(line); i++ {
+		if line[i] == '#' && i > off && (line[i-1] == ' ' || line[i-1] == '\t') {
+			break
+		}
+		if isMappingIndicator(line, i) {
+			node.Value = strings.TrimRight(line[off:i], " \t")
+			return node, i + 1, node.Value != "", nil
+		}
+	}
+
+	return nil, 0, false, nil
+}
+
+// scalar parses the scalar at offset off of the current line, whose parent node has the given indentation.
+// It consumes the lines of the scalar and returns the comment following it on the same line.
+func (p *parser) scalar(parent int, off int) (*Node, string, error) {
+	line := p.lines[p.n]
+	node := &Node{Kind: ScalarNode, Line: p.n + 1, Column: p.column(off)}
+
+	var end int
+	switch c := line[off]; c {
+	case '|', '>':
+		return p.blockScalar(parent, off)
+	case '"', '\'':
+		value, e, err := p.quoted(line, off)
+		if err != nil {
+			return nil, "", err
+		}
+		node.Value = value
+		node.Style = SingleQuotedStyle
+		if c == '"' {
+			node.Style = DoubleQuotedStyle
+		}
+		end = e
+	case '[', '{':
+		flow, e, err := p.flow(line, off)
+		if err != nil {
+			return nil, "", err
+		}
+		node = flow
+		end = e
+	case '&', '*', '!':
+		return nil, "", p.errorf(off, "anchors, aliases and tags are not supported")
+	case '%', '@', '`', ',', ']', '}':
+		return nil, "", p.errorf(off, "character %q cannot start a plain scalar", c)
+	case '?', ':':
+		if isSpaceAt(line, off+1) {
+			return nil, "", p.errorf(off, "complex mapping keys are not supported")
+		}
+		return p.plain(parent, off)
+	default:
+		return p.plain(parent, off)
+	}
+
+	comment, err := p.lineEnd(end)
+	if err != nil {
+		return nil, "", err
+	}
+	p.n++
+	return node, comment, nil
+}
+
+// lineEnd checks that the current line has nothing but an optional comment after offset off and returns the comment.
+func (p *parser) lineEnd(off int) (string, error) {
+	line := p.lines[p.n]
+	i := skipSpace(line, off)
+	if i == len(line) {
+		return "", nil
+	}
+	if line[i] != '#' || i == off {
+		return "", p.errorf(i, "unexpected content after the value")
+	}
+	return strings.TrimRight(line[i:], " \t"), nil
+}
+
+// plain parses a plain scalar, which may continue on more indented lines.
+func (p *parser) plain(parent int, off int) (*Node, string, error) {
+	line := p.lines[p.n]
+	node := &Node{Kind: ScalarNode, Line: p.n + 1, Column: p.column(off)}
+
+	end, comment := len(line), ""
+	for i := off + 1; i < len(line); i++ {
+		if line[i] == '#' && (line[i-1] == ' ' || line[i-1] == '\t') {
+			end, comment = i, strings.TrimRight(line[i:], " \t")
+			break
+		}
+	}
+	var value strings.Builder
+	value.WriteString(strings.TrimRight(line[off:end], " \t"))
+	p.n++
+
+	if comment != "" {
+		node.Value = value.String()
+		return node, comment, nil
+	}
+
+	// continuation lines are folded into a single space or kept as line breaks if separated by empty lines
+	for empty, i := 0, p.n; i < len(p.lines); i++ {
+		text := strings.TrimLeft(p.lines[i], " \t")
+		if text == "" {
+			empty++
+			continue
+		}
+		indent := indentation(p.lines[i])
+		if indent <= parent || text[0] == '#' || isMarker(p.lines[i], "---") || isMarker(p.lines[i], "...") {
+			break
+		}
+
+		p.n = i
+		if _, _, ok, err := p.key(indent); err != nil || ok {
+			if err == nil {
+				err = p.errorf(indent, "mapping values are not allowed here")
+			}
+			return nil, "", err
+		}
+
+		end = len(text)
+		for j := 1; j < len(text); j++ {
+			if text[j] == '#' && (text[j-1] == ' ' || text[j-1] == '\t') {
+				end, comment = j, strings.TrimRight(text[j:], " \t")
+				break
+			}
+		}
+		if empty == 0 {
+			value.WriteByte(' ')
+		} else {
+			value.WriteString(strings.Repeat("\n", empty))
+		}
+		value.WriteString(strings.TrimRight(text[:end], " \t"))
+		empty = 0
+		p.n = i + 1
+
+		if comment != "" {
+			break
+		}
+	}
+
+	node.Value = value.String()
+	return node, comment, nil
+}
+
+// blockScalar parses a literal or folded block scalar.
+func (p *parser) blockScalar(parent int, off int) (*Node, string, error) {
+	line := p.lines[p.n]
+	node := &Node{Kind: ScalarNode, Style: LiteralStyle, Line: p.n + 1, Column: p.column(off)}
+	if line[off] == '>' {
+		node.Style = FoldedStyle
+	}
+
+	chomping, indent := byte(0), 0
+	i := off + 1
+	for ; i < len(line) && line[i] != ' ' && line[i] != '\t'; i++ {
+		switch c := line[i]; {
+		case (c == '-' || c == '+') && chomping == 0:
+			chomping = c
+		case c >= '1' && c <= '9' && indent == 0:
+			indent = int(c - '0')
+		default:
+			return nil, "", p.errorf(i, "invalid block scalar header")
+		}
+	}
+	comment, err := p.lineEnd(i)
+	if err != nil {
+		return nil, "", err
+	}
+	p.n++
+
+	minimum := parent + 1
+	if minimum < 0 {
+		minimum = 0
+	}
+	content := 0
+	if indent > 0 {
+		content = minimum + indent - 1
+		if parent < 0 {
+			content = indent
+		}
+	}
+
+	var lines []string
+	trailing := 0
+	for ; p.n < len(p.lines); p.n++ {
+		text := p.lines[p.n]
+		if strings.TrimLeft(text, " ") == "" {
+			if content > 0 && len(text) > content {
+				lines = append(lines, text[content:])
+			} else {
+				lines = append(lines, "")
+			}
+			trailing++
+			continue
+		}
+
+		n := indentation(text)
+		if indent == 0 && content == 0 {
+			if n < minimum || (n == 0 && minimum == 0 && (isMarker(text, "---") || isMarker(text, "..."))) {
+				break
+			}
+			content = n
+		}
+		if n < content || (content == 0 && (isMarker(text, "---") || isMarker(text, "..."))) {
+			break
+		}
+		lines = append(lines, text[content:])
+		trailing = 0
+	}
+	lines, blank := lines[:len(lines)-trailing], lines[len(lines)-trailing:]
+
+	var value strings.Builder
+	if node.Style == LiteralStyle {
+		value.WriteString(strings.Join(lines, "\n"))
+	} else {
+		value.WriteString(fold(lines))
+	}
+
+	switch {
+	case chomping == '+':
+		if len(lines) > 0 {
+			value.WriteByte('\n')
+		}
+		value.WriteString(strings.Repeat("\n", len(blank)))
+	case len(lines) == 0 || chomping == '-':
+	default:
+		value.WriteByte('\n')
+	}
+	if chomping != '+' && len(blank) > 0 {
+		p.pending = append(p.pending, "")
+	}
+
+	node.Value = value.String()
+	return node, comment, nil
+}
+
+// fold joins the lines of a folded block scalar. Lines are joined by a space unless separated by
+// empty lines or more indented, in which case the line breaks are kept.
+func fold(lines []string) string {
+	var b strings.Builder
+	empty := 0
+	started, previousMore := false, false
+	for _, line := range lines {
+		if line == "" {
+			empty++
+			continue
+		}
+
+		more := line[0] == ' ' || line[0] == '\t'
+		switch {
+		case !started:
+			b.WriteString(strings.Repeat("\n", empty))
+		case empty == 0 && !more && !previousMore:
+			b.WriteByte(' ')
+		case empty == 0:
+			b.WriteByte('\n')
+		case !more && !previousMore:
+			b.WriteString(strings.Repeat("\n", empty))
+		default:
+			b.WriteString(strings.Repeat("\n", empty+1))
+		}
+		b.WriteString(line)
+
+		started, previousMore, empty = true, more, 0
+	}
+	return b.String()
+}
+
+// flow parses a flow collection at offset off, which must end on the same line.
+// It returns the collection and the offset following it.
+func (p *parser) flow(line string, off int) (*Node, int, error) {
+	if err := p.enter(off); err != nil {
+		return nil, 0, err
+	}
+	defer p.leave()
+	node := &Node{Kind: SequenceNode, Style: FlowStyle, Line: p.n + 1, Column: p.column(off)}
+	closing := byte(']')
+	if line[off] == '{' {
+		node.Kind = MappingNode
+		closing = '}'
+	}
+	seen := make(map[string]bool)
+
+	i := off + 1
+	for {
+		i = skipSpace(line, i)
+		if i == len(line) || line[i] == '#' {
+			return nil, 0, p.errorf(off, "flow collection must be closed on the same line")
+		}
+		if line[i] == closing {
+			return node, i + 1, nil
+		}
+
+		at := i
+		item, end, err := p.flowNode(line, i)
+		if err != nil {
+			return nil, 0, err
+		}
+		i = skipSpace(line, end)
+
+		if node.Kind == MappingNode {
+			if item.Kind != ScalarNode {
+				return nil, 0, p.errorf(at, "complex mapping keys are not supported")
+			}
+			if seen[item.Value] {
+				return nil, 0, p.errorf(at, "duplicate key %q", item.Value)
+			}
+			seen[item.Value] = true
+
+			value := &Node{Kind: ScalarNode, Line: p.n + 1, Column: p.column(i)}
+			if i < len(line) && line[i] == ':' {
+				i = skipSpace(line, i+1)
+				if i < len(line) && line[i] != ',' && line[i] != closing {
+					value, end, err = p.flowNode(line, i)
+					if err != nil {
+						return nil, 0, err
+					}
+					i = skipSpace(line, end)
+				}
+			}
+			node.Content = append(node.Content, item, value)
+		} else {
+			if i < len(line) && line[i] == ':' {
+				return nil, 0, p.errorf(i, "mappings in flow sequences are not supported")
+			}
+			node.Content = append(node.Content, item)
+		}
+
+		if i < len(line) && line[i] == ',' {
+			i++
+		} else if i >= len(line) || line[i] != closing {
+			return nil, 0, p.errorf(i, "flow collection entries must be separated by ,")
+		}
+	}
+}
+
+// flowNode parses a node inside a flow collection and returns the offset following it.
+func (p *parser) flowNode(line string, off int) (*Node, int, error) {
+	node := &Node{Kind: ScalarNode, Line: p.n + 1, Column: p.column(off)}
+
+	switch c := line[off]; c {
+	case '[', '{':
+		return p.flow(line, off)
+	case '"', '\'':
+		value, end, err := p.quoted(line, off)
+		if err != nil {
+			return nil, 0, err
+		}
+		node.Value = value
+		node.Style = SingleQuotedStyle
+		if c == '"' {
+			node.Style = DoubleQuotedStyle
+		}
+		return node, end, nil
+	case '&', '*', '!':
+		return nil, 0, p.errorf(off, "anchors, aliases and tags are not supported")
+	case '%', '@', '`', ',', ']', '}', '#', '|', '>':
+		return nil, 0, p.errorf(off, "character %q cannot start a plain scalar", c)
+	}
+
+	end := off
+	for ; end < len(line); end++ {
+		c := line[end]
+		if strings.IndexByte(",[]{}", c) >= 0 {
+			break
+		}
+		if c == ':' && (end+1 == len(line) || strings.IndexByte(" \t,[]{}", line[end+1]) >= 0) {
+			break
+		}
+		if c == '#' && (line[end-1] == ' ' || line[end-1] == '\t') {
+			break
+		}
+	}
+	node.Value = strings.TrimRight(line[off:end], " \t")
+	return node, end, nil
+}
+
+// quoted parses the single or double quoted scalar at offset off and returns the offset following it.
+func (p *parser) quoted(line string, off int) (string, int, error) {
+	if line[off] == '\'' {
+		var b strings.Builder
+		for i := off + 1; i < len(line); i++ {
+			if line[i] != '\'' {
+				b.WriteByte(line[i])
+				continue
+			}
+			if i+1 < len(line) && line[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String(), i + 1, nil
+		}
+		return "", 0, p.errorf(off, "quoted scalar must be closed on the same line")
+	}
+
+	var b strings.Builder
+	for i := off + 1; i < len(line); i++ {
+		switch c := line[i]; c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 == len(line) {
+				return "", 0, p.errorf(off, "quoted scalar must be closed on the same line")
+			}
+			n, err := unescape(&b, line[i+1:])
+			if err != nil {
+				return "", 0, p.errorf(i, "%v", err)
+			}
+			i += n
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, p.errorf(off, "quoted scalar must be closed on the same line")
+}
+
+// escapes maps the characters following a backslash to the escaped characters.
+var escapes = map[byte]string{
+	'0': "\x00", 'a': "\a", 'b': "\b", 't': "\t", '\t': "\t", 'n': "\n", 'v': "\v", 'f': "\f",
+	'r': "\r", 'e': "\x1b", ' ': " ", '"': "\"", '/': "/", '\\': "\\",
+	'N': "\u0085", '_': "\u00a0", 'L': "\u2028", 'P': "\u2029",
+}
+
+// unescape writes the character of the escape sequence following a backslash and returns its length.
+func unescape(b *strings.Builder, s string) (int, error) {
+	if r, ok := escapes[s[0]]; ok {
+		b.WriteString(r)
+		return 1, nil
+	}
+
+	digits := map[byte]int{'x': 2, 'u': 4, 'U': 8}[s[0]]
+	if digits == 0 {
+		return 0, fmt.Errorf("invalid escape sequence \\%c", s[0])
+	}
+	if len(s) <= digits {
+		return 0, fmt.Errorf("escape sequence \\%c requires %d hexadecimal digits", s[0], digits)
+	}
+	var r rune
+	for _, c := range s[1 : digits+1] {
+		var d rune
+		switch {
+		case c >= '0' && c <= '9':
+			d = c - '0'
+		case c >= 'a' && c <= 'f':
+			d = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			d = c - 'A' + 10
+		default:
+			return 0, fmt.Errorf("escape sequence \\%c requires %d hexadecimal digits", s[0], digits)
+		}
+		r = r<<4 | d
+	}
+	if !utf8.ValidRune(r) {
+		return 0, fmt.Errorf("invalid unicode character in escape sequence \\%s", s[:digits+1])
+	}
+	b.WriteRune(r)
+	return digits + 1, nil
+}
+
+// skipEmpty skips empty and comment lines and keeps them as pending comments.
+func (p *parser) skipEmpty() {
+	for ; !p.eof(); p.n++ {
+		line := p.lines[p.n]
+		text := strings.TrimLeft(line, " \t")
+		switch {
+		case text == "":
+			if len(p.pending) == 0 || p.pending[len(p.pending)-1] != "" {
+				p.pending = append(p.pending, "")
+			}
+		case text[0] == '#':
+			p.pending = append(p.pending, strings.TrimRight(text, " \t"))
+		default:
+			return
+		}
+	}
+}
+
+// takePending returns and clears the pending comments.
+func (p *parser) takePending() []string {
+	pending := p.pending
+	p.pending = nil
+	return pending
+}
+
+// takeFoot returns and clears the pending comments without surrounding empty lines.
+func (p *parser) takeFoot() []string {
+	foot := trimBlank(p.takePending())
+	if len(foot) == 0 {
+		return nil
+	}
+	return foot
+}
+
+// indent returns the indentation of the current line, which must consist of spaces.
+func (p *parser) indent() (int, error) {
+	line := p.lines[p.n]
+	n := indentation(line)
+	if n < len(line) && line[n] == '\t' {
+		return 0, p.errorf(n, "tabs are not allowed for indentation")
+	}
+	return n, nil
+}
+
+func (p *parser) eof() bool {
+	return p.n >= len(p.lines)
+}
+
+// isDocumentBoundary reports whether the current line is a document marker.
+func (p *parser) isDocumentBoundary() bool {
+	return isMarker(p.lines[p.n], "---") || isMarker(p.lines[p.n], "...")
+}
+
+// enter enters a collection starting at offset off of the current line.
+// It returns an error if the collection is nested deeper than maxDepth.
+func (p *parser) enter(off int) error {
+	if p.depth == maxDepth {
+		return p.errorf(off, "collections must not be nested deeper than %d levels", maxDepth)
+	}
+	p.depth++
+	return nil
+}
+
+// leave leaves the collection entered last.
+func (p *parser) leave() {
+	p.depth--
+}
+
+// column returns the 1-based column of the byte offset in the current line.
+// The runes are counted from the last computed column if it precedes the offset on the same line,
+// which keeps parsing a long line linear.
+func (p *parser) column(off int) int {
+	line := p.lines[p.n]
+	if off > len(line) {
+		off = len(line)
+	}
+	if p.columnLine != p.n || p.columnOff > off {
+		p.columnLine, p.columnOff, p.columnRunes = p.n, 0, 0
+	}
+	p.columnRunes += utf8.RuneCountInString(line[p.columnOff:off])
+	p.columnOff = off
+	return p.columnRunes + 1
+}
+
+// errorf returns an error at the byte offset in the current line.
+func (p *parser) errorf(off int, format string, args ...interface{}) error {
+	return &Error{Line: p.n + 1, Column: p.column(off), Message: fmt.Sprintf(format, args...)}
+}
+
+// trimBlank removes leading and trailing empty lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// indentation returns the number of leading spaces.
+func indentation(line string) int {
+	n := 0
+	for n < len(line) && line[n] == ' ' {
+		n++
+	}
+	return n
+}
+
+func skipSpace(line string, off int) int {
+	for off < len(line) && (line[off] == ' ' || line[off] == '\t') {
+		off++
+	}
+	return off
+}
+
+// isSpaceAt reports whether the line has a space or ends at offset i.
+func isSpaceAt(line string, i int) bool {
+	return i >= len(line) || line[i] == ' ' || line[i] == '\t'
+}
+
+// isMappingIndicator reports whether the line has a ':' followed by a space or the line end at offset i.
+func isMappingIndicator(line string, i int) bool {
+	return i < len(line) && line[i] == ':' && isSpaceAt(line, i+1)
+}
+
+// isSequenceEntry reports whether the line has a '-' followed by a space or the line end at offset i.
+func isSequenceEntry(line string, i int) bool {
+	return i < len(line) && line[i] == '-' && isSpaceAt(line, i+1)
+}
+
+// isMarker reports whether the line is the document marker optionally followed by a comment.
+func isMarker(line string, marker string) bool {
+	return strings.HasPrefix(line, marker) && isSpaceAt(line, len(marker))
+}
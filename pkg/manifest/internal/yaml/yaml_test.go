@@ -0,0 +1,190 @@
+package yaml
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"is empty", "", nil},
+		{"is mapping", "a: 1\nb: two words\n", []string{`{a: "1", b: "two words"}`}},
+		{"has nested mapping", "a:\n  b:\n    c: x\n  d: y\ne: z\n", []string{`{a: {b: {c: "x"}, d: "y"}, e: "z"}`}},
+		{"has sequence", "a:\n  - x\n  - y\n", []string{`{a: ["x", "y"]}`}},
+		{"has indentless sequence", "a:\n- x\n- y\nb: z\n", []string{`{a: ["x", "y"], b: "z"}`}},
+		{"has compact mappings", "- a: 1\n  b: 2\n- - x\n  - y\n-\n  c: 3\n", []string{`[{a: "1", b: "2"}, ["x", "y"], {c: "3"}]`}},
+		{"has nulls", "a:\nb: ~\nc: null\nd: 'null'\n-: x\n", []string{`{a: null, b: null, c: null, d: "null", -: "x"}`}},
+		{"has quoted scalars", `a: 'it''s # not a comment'
+b: "tab\tnew\nline \"q\" \\ \u00e9 \x41 \U0001F600"
+'c d': "e: f"
+`, []string{`{a: "it's # not a comment", b: "tab\tnew\nline \"q\" \\ é A 😀", c d: "e: f"}`}},
+		{"has plain scalars with indicators", "url: http://example.com/a#b\ntext: a-b:c\n", []string{`{url: "http://example.com/a#b", text: "a-b:c"}`}},
+		{"has multi-line plain scalar", "a: first\n  second\n\n  third\nb: x\n", []string{`{a: "first second\nthird", b: "x"}`}},
+		{"has flow collections", "a: [x, 'y', [z], {k: v}, ]\nb: {k: [1, 2], e: , f}\nc: []\nd: {}\n", []string{`{a: ["x", "y", ["z"], {k: "v"}], b: {k: ["1", "2"], e: null, f: null}, c: [], d: {}}`}},
+		{"has literal block scalars", "a: |\n  one\n    two\n\n  three\n\nb: |-\n  x\nc: |+\n  y\n\nd: |2\n    z\n", []string{`{a: "one\n  two\n\nthree\n", b: "x", c: "y\n\n", d: "  z\n"}`}},
+		{"has folded block scalars", "a: >\n  one\n  two\n\n  three\n    more\n  four\nb: >-\n  x\n  y\n", []string{`{a: "one two\nthree\n  more\nfour\n", b: "x y"}`}},
+		{"has block scalar in sequence", "- |\n  x\n- y\n", []string{`["x\n", "y"]`}},
+		{"has multiple documents", "a: 1\n---\nb: 2\n...\n---\n- c\n", []string{`{a: "1"}`, `{b: "2"}`, `["c"]`}},
+		{"has empty documents", "---\n---\na: 1\n---\n", []string{`null`, `{a: "1"}`, `null`}},
+		{"has leading document marker", "# comment\n---\na: 1\n", []string{`{a: "1"}`}},
+		{"is scalar", "text # comment\n", []string{`"text"`}},
+		{"has windows line endings", "a: 1\r\nb: 2\r\n", []string{`{a: "1", b: "2"}`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := Parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			var got []string
+			for _, doc := range docs {
+				got = append(got, dump(doc.Root))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_comments(t *testing.T) {
+	data := `# head of a
+
+a: 1 # line of a
+# head of b
+b: # line of b
+  # head of c
+  c: 2
+
+  # head of item
+  d:
+    - x # line of x
+    - # line of mapping
+      e: 3
+# foot
+---
+# only a comment
+`
+	docs, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("Parse() returned %d documents, want 2", len(docs))
+	}
+
+	root := docs[0].Root
+	a, b := root.Content[0], root.Content[2]
+	c, d := root.Content[3].Content[0], root.Content[3].Content[2]
+	x, mapping := root.Content[3].Content[3].Content[0], root.Content[3].Content[3].Content[1]
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"head of a", a.HeadComment, []string{"# head of a", ""}},
+		{"line of a", a.LineComment, "# line of a"},
+		{"head of b", b.HeadComment, []string{"# head of b"}},
+		{"line of b", b.LineComment, "# line of b"},
+		{"head of c", c.HeadComment, []string{"# head of c"}},
+		{"head of d", d.HeadComment, []string{"", "# head of item"}},
+		{"line of x", x.LineComment, "# line of x"},
+		{"line of mapping", mapping.LineComment, "# line of mapping"},
+		{"foot", docs[0].FootComment, []string{"# foot"}},
+		{"only a comment", docs[1].FootComment, []string{"# only a comment"}},
+		{"position of e", [2]int{mapping.Content[0].Line, mapping.Content[0].Column}, [2]int{13, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"has unexpected indentation", "a: 1\n  b: 2\n", "yaml: line 2, column 3: mapping values are not allowed here"},
+		{"has over-indented key", "a:\n  b:\n    c: 1\n   d: 2\n", "yaml: line 4, column 4: unexpected indentation"},
+		{"has over-indented key after scalar", "a:\n  b: 1\n   c: 2\n", "yaml: line 3, column 4: mapping values are not allowed here"},
+		{"has duplicate key", "a: 1\na: 2\n", `yaml: line 2, column 1: duplicate key "a"`},
+		{"has inline mapping value", "a: b: c\n", "yaml: line 1, column 4: mapping values are not allowed here"},
+		{"has inline sequence", "a: - b\n", "yaml: line 1, column 4: block sequence must start on a new line"},
+		{"has tab indentation", "a:\n\tb: 1\n", "yaml: line 2, column 1: tabs are not allowed for indentation"},
+		{"has unclosed quote", "a: 'b\n", "yaml: line 1, column 4: quoted scalar must be closed on the same line"},
+		{"has invalid escape", `a: "\q"`, `yaml: line 1, column 5: invalid escape sequence \q`},
+		{"has unclosed flow collection", "a: [b,\n  c]\n", "yaml: line 1, column 4: flow collection must be closed on the same line"},
+		{"has content after quoted scalar", "a: 'b' c\n", "yaml: line 1, column 8: unexpected content after the value"},
+		{"has anchor", "a: &x b\n", "yaml: line 1, column 4: anchors, aliases and tags are not supported"},
+		{"has complex key", "? a\n", "yaml: line 1, column 1: complex mapping keys are not supported"},
+		{"has directive", "%YAML 1.2\n---\na: 1\n", "yaml: line 1, column 1: directives are not supported"},
+		{"has invalid block scalar header", "a: |x\n", "yaml: line 1, column 5: invalid block scalar header"},
+		{"has content after root", "- a\nb: 1\n", "yaml: line 2, column 1: unexpected content after the document root"},
+		{"has multi-byte characters before error", "ä: 'b\n", "yaml: line 1, column 4: quoted scalar must be closed on the same line"},
+		{"has deeply nested flow collections", "a: " + strings.Repeat("[", maxDepth+1), "yaml: line 1, column 10003: collections must not be nested deeper than 10000 levels"},
+		{"has deeply nested block sequences", strings.Repeat("- ", maxDepth+1) + "x\n", "yaml: line 1, column 20001: collections must not be nested deeper than 10000 levels"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			var yamlErr *Error
+			if !errors.As(err, &yamlErr) {
+				t.Fatalf("Parse() error = %v, want *Error", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Parse() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_longLine(t *testing.T) {
+	items := strings.Repeat("ä, ", 100000)
+	docs, err := Parse([]byte("a: [" + items + "'b']\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	seq := docs[0].Root.Content[1]
+	if len(seq.Content) != 100001 {
+		t.Fatalf("Parse() returned %d items, want 100001", len(seq.Content))
+	}
+	if last := seq.Content[100000]; last.Value != "b" || last.Column != 300005 {
+		t.Errorf("Parse() returned last item %q at column %d, want \"b\" at column 300005", last.Value, last.Column)
+	}
+}
+
+// dump returns a compact representation of the node tree with quoted scalars.
+func dump(n *Node) string {
+	if n.IsNull() {
+		return "null"
+	}
+
+	var items []string
+	switch n.Kind {
+	case MappingNode:
+		for i := 0; i < len(n.Content); i += 2 {
+			items = append(items, n.Content[i].Value+": "+dump(n.Content[i+1]))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case SequenceNode:
+		for _, item := range n.Content {
+			items = append(items, dump(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return strconv.Quote(n.Value)
+	}
+}
@@ -0,0 +1,434 @@
+// Package manifest reads and writes modules as hand-written YAML manifests.
+//
+// A manifest maps the module fields one-to-one to the fields of the specification:
+//
+//	namespace: com.example
+//	name: product
+//	type: go
+//	version:
+//	  name: v1.1.0
+//	  schema: semver
+//	  replaces:
+//	    - v1.0.0
+//	annotations:
+//	  com.example.team: platform
+//	dependencies:
+//	  - namespace: com.example
+//	    name: lib
+//	    type: go
+//	    version: v1.0.0
+//	    direction: UPSTREAM
+//
+// A manifest file may contain multiple modules as separate YAML documents.
+// Comments are kept when a parsed file is written back.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/opendependency/go-spec/pkg/manifest/internal/yaml"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// FileName is the conventional name of a manifest file.
+const FileName = "opendependency.yaml"
+
+// Error reports a syntax error or a field violating the manifest schema at a position in the manifest file.
+type Error struct {
+	// Line and Column are the 1-based position of the error.
+	Line   int
+	Column int
+	// Message describes the error.
+	Message string
+}
+
+// Error returns the position followed by the message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("manifest: line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// File is a parsed manifest file.
+type File struct {
+	// Modules are the modules of the file in document order.
+	Modules []*v1.Module
+
+	documents []*yaml.Document
+}
+
+// Parse decodes the manifest file. A *Error is returned if the file is not valid YAML
+// or does not follow the manifest schema. Empty documents are skipped.
+func Parse(data []byte) (*File, error) {
+	docs, err := yaml.Parse(data)
+	if err != nil {
+		var yamlErr *yaml.Error
+		if errors.As(err, &yamlErr) {
+			return nil, &Error{Line: yamlErr.Line, Column: yamlErr.Column, Message: yamlErr.Message}
+		}
+		return nil, err
+	}
+
+	f := &File{documents: docs}
+	for _, doc := range docs {
+		if doc.Root == nil {
+			continue
+		}
+		module, err := decodeModule(doc.Root)
+		if err != nil {
+			return nil, err
+		}
+		f.Modules = append(f.Modules, module)
+	}
+
+	return f, nil
+}
+
+// Read decodes the modules of the manifest file read from r.
+func Read(r io.Reader) ([]*v1.Module, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return f.Modules, nil
+}
+
+// Write writes the modules as manifest file with one YAML document per module.
+func Write(w io.Writer, modules ...*v1.Module) error {
+	return (&File{Modules: modules}).Write(w)
+}
+
+// Write writes the modules of the file in canonical form.
+// The comments of the parsed file are kept for the fields and list items still present,
+// whereas the modules are matched to the documents by their order.
+func (f *File) Write(w io.Writer) error {
+	var docs []*yaml.Document
+	i := 0
+	for _, doc := range f.documents {
+		if doc.Root == nil {
+			docs = append(docs, doc)
+			continue
+		}
+		if i == len(f.Modules) {
+			continue
+		}
+
+		root := encodeModule(f.Modules[i])
+		transferComments(doc.Root, root)
+		docs = append(docs, &yaml.Document{Root: root, FootComment: doc.FootComment})
+		i++
+	}
+	for ; i < len(f.Modules); i++ {
+		docs = append(docs, &yaml.Document{Root: encodeModule(f.Modules[i])})
+	}
+
+	_, err := w.Write(yaml.Encode(docs))
+	return err
+}
+
+// transferComments copies the comments of the mapping keys and sequence items from one node tree to another.
+// Mapping entries are matched by key, sequence items by index.
+func transferComments(from *yaml.Node, to *yaml.Node) {
+	if from.Kind != to.Kind {
+		return
+	}
+
+	switch to.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(to.Content); i += 2 {
+			for j := 0; j+1 < len(from.Content); j += 2 {
+				if from.Content[j].Value != to.Content[i].Value {
+					continue
+				}
+				to.Content[i].HeadComment = from.Content[j].HeadComment
+				to.Content[i].LineComment = from.Content[j].LineComment
+				transferComments(from.Content[j+1], to.Content[i+1])
+				break
+			}
+		}
+	case yaml.SequenceNode:
+		for i := 0; i < len(to.Content) && i < len(from.Content); i++ {
+			to.Content[i].HeadComment = from.Content[i].HeadComment
+			to.Content[i].LineComment = from.Content[i].LineComment
+			transferComments(from.Content[i], to.Content[i])
+		}
+	}
+}
+
+func decodeModule(n *yaml.Node) (*v1.Module, error) {
+	m := &v1.Module{}
+	err := decodeMapping(n, "", func(key string, value *yaml.Node, path string) (bool, error) {
+		var err error
+		switch key {
+		case "namespace":
+			m.Namespace, err = decodeString(value, path)
+		case "name":
+			m.Name, err = decodeString(value, path)
+		case "type":
+			m.Type, err = decodeString(value, path)
+		case "version":
+			m.Version, err = decodeVersion(value, path)
+		case "annotations":
+			m.Annotations, err = decodeAnnotations(value, path)
+		case "dependencies":
+			m.Dependencies, err = decodeDependencies(value, path)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	return m, err
+}
+
+func decodeVersion(n *yaml.Node, path string) (*v1.ModuleVersion, error) {
+	if n.IsNull() {
+		return nil, nil
+	}
+
+	v := &v1.ModuleVersion{}
+	err := decodeMapping(n, path, func(key string, value *yaml.Node, path string) (bool, error) {
+		var err error
+		switch key {
+		case "name":
+			v.Name, err = decodeString(value, path)
+		case "schema":
+			if !value.IsNull() {
+				var schema string
+				schema, err = decodeString(value, path)
+				v.Schema = &schema
+			}
+		case "replaces":
+			v.Replaces, err = decodeStrings(value, path)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	return v, err
+}
+
+func decodeAnnotations(n *yaml.Node, path string) (map[string]string, error) {
+	if n.IsNull() {
+		return nil, nil
+	}
+
+	annotations := make(map[string]string)
+	err := decodeMapping(n, path, func(key string, value *yaml.Node, path string) (bool, error) {
+		v, err := decodeString(value, path)
+		annotations[key] = v
+		return true, err
+	})
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	return annotations, err
+}
+
+func decodeDependencies(n *yaml.Node, path string) ([]*v1.ModuleDependency, error) {
+	if n.IsNull() {
+		return nil, nil
+	}
+	if n.Kind != yaml.SequenceNode {
+		return nil, errorf(n, path, "must be a list")
+	}
+
+	var dependencies []*v1.ModuleDependency
+	for i, item := range n.Content {
+		d := &v1.ModuleDependency{}
+		err := decodeMapping(item, path+"["+strconv.Itoa(i)+"]", func(key string, value *yaml.Node, path string) (bool, error) {
+			var err error
+			switch key {
+			case "namespace":
+				d.Namespace, err = decodeString(value, path)
+			case "name":
+				d.Name, err = decodeString(value, path)
+			case "type":
+				d.Type, err = decodeString(value, path)
+			case "version":
+				d.Version, err = decodeString(value, path)
+			case "direction":
+				d.Direction, err = decodeDirection(value, path)
+			default:
+				return false, nil
+			}
+			return true, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, d)
+	}
+	return dependencies, nil
+}
+
+// decodeDirection decodes a dependency direction given by its name or number.
+// Unknown numbers are accepted like in the binary encoding.
+func decodeDirection(n *yaml.Node, path string) (*v1.DependencyDirection, error) {
+	if n.IsNull() {
+		return nil, nil
+	}
+
+	s, err := decodeString(n, path)
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := v1.DependencyDirection_value[s]; ok {
+		return v1.DependencyDirection(value).Enum(), nil
+	}
+	if number, err := strconv.ParseInt(s, 10, 32); err == nil {
+		return v1.DependencyDirection(number).Enum(), nil
+	}
+	return nil, errorf(n, path, "unknown dependency direction %q, must be UPSTREAM or DOWNSTREAM", s)
+}
+
+// decodeMapping calls field for each entry of the mapping. The field function reports whether the key is known.
+func decodeMapping(n *yaml.Node, path string, field func(key string, value *yaml.Node, path string) (bool, error)) error {
+	if n.Kind != yaml.MappingNode {
+		return errorf(n, path, "must be a mapping")
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if key.Kind != yaml.ScalarNode {
+			return errorf(key, path, "keys must be strings")
+		}
+
+		fieldPath := key.Value
+		if path != "" {
+			fieldPath = path + "." + key.Value
+		}
+		known, err := field(key.Value, value, fieldPath)
+		if err != nil {
+			return err
+		}
+		if !known {
+			return errorf(key, path, "unknown field %q", key.Value)
+		}
+	}
+	return nil
+}
+
+// decodeString decodes a scalar, whereas null becomes the empty string.
+func decodeString(n *yaml.Node, path string) (string, error) {
+	if n.Kind != yaml.ScalarNode {
+		return "", errorf(n, path, "must be a string")
+	}
+	if n.IsNull() {
+		return "", nil
+	}
+	return n.Value, nil
+}
+
+func decodeStrings(n *yaml.Node, path string) ([]string, error) {
+	if n.IsNull() {
+		return nil, nil
+	}
+	if n.Kind != yaml.SequenceNode {
+		return nil, errorf(n, path, "must be a list")
+	}
+
+	var values []string
+	for i, item := range n.Content {
+		value, err := decodeString(item, path+"["+strconv.Itoa(i)+"]")
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// errorf returns an error at the position of the node, whose message is prefixed by the field path if any.
+func errorf(n *yaml.Node, path string, format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	if path != "" {
+		message = path + ": " + message
+	}
+	return &Error{Line: n.Line, Column: n.Column, Message: message}
+}
+
+// encodeModule encodes the module in the field order of the specification.
+// Empty fields are omitted, except for explicitly set optional fields.
+func encodeModule(m *v1.Module) *yaml.Node {
+	n := mapping()
+	addString(n, "namespace", m.GetNamespace())
+	addString(n, "name", m.GetName())
+	addString(n, "type", m.GetType())
+
+	if version := m.GetVersion(); version != nil {
+		v := mapping()
+		addString(v, "name", version.GetName())
+		if version.Schema != nil {
+			add(v, "schema", scalar(version.GetSchema()))
+		}
+		if len(version.GetReplaces()) > 0 {
+			replaces := &yaml.Node{Kind: yaml.SequenceNode}
+			for _, r := range version.GetReplaces() {
+				replaces.Content = append(replaces.Content, scalar(r))
+			}
+			add(v, "replaces", replaces)
+		}
+		add(n, "version", v)
+	}
+
+	if len(m.GetAnnotations()) > 0 {
+		annotations := mapping()
+		keys := make([]string, 0, len(m.GetAnnotations()))
+		for k := range m.GetAnnotations() {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			add(annotations, k, scalar(m.GetAnnotations()[k]))
+		}
+		add(n, "annotations", annotations)
+	}
+
+	if len(m.GetDependencies()) > 0 {
+		dependencies := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, d := range m.GetDependencies() {
+			dependency := mapping()
+			addString(dependency, "namespace", d.GetNamespace())
+			addString(dependency, "name", d.GetName())
+			addString(dependency, "type", d.GetType())
+			addString(dependency, "version", d.GetVersion())
+			if d != nil && d.Direction != nil {
+				direction, ok := v1.DependencyDirection_name[int32(*d.Direction)]
+				if !ok {
+					direction = strconv.Itoa(int(*d.Direction))
+				}
+				add(dependency, "direction", scalar(direction))
+			}
+			dependencies.Content = append(dependencies.Content, dependency)
+		}
+		add(n, "dependencies", dependencies)
+	}
+
+	return n
+}
+
+func mapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode}
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value, Style: yaml.DoubleQuotedStyle}
+}
+
+func add(n *yaml.Node, key string, value *yaml.Node) {
+	n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+// addString adds the string field unless it is empty.
+func addString(n *yaml.Node, key string, value string) {
+	if value != "" {
+		add(n, key, scalar(value))
+	}
+}
@@ -0,0 +1,252 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func product() *v1.Module {
+	schema := "semver"
+	return &v1.Module{
+		Namespace: "com.example",
+		Name:      "product",
+		Type:      "go",
+		Version: &v1.ModuleVersion{
+			Name:     "v1.1.0",
+			Schema:   &schema,
+			Replaces: []string{"v1.0.0"},
+		},
+		Annotations: map[string]string{
+			"com.example.team":        "platform",
+			"com.example.description": "first line\nsecond line\n",
+			"com.example.priority":    "1",
+		},
+		Dependencies: []*v1.ModuleDependency{
+			{Namespace: "com.example", Name: "lib", Type: "go", Version: "v1.0.0"},
+			{Namespace: "com.example", Name: "app", Type: "go", Version: "v2.0.0", Direction: v1.DependencyDirection_DOWNSTREAM.Enum()},
+		},
+	}
+}
+
+const productManifest = `namespace: com.example
+name: product
+type: go
+version:
+  name: v1.1.0
+  schema: semver
+  replaces:
+    - v1.0.0
+annotations:
+  com.example.description: |
+    first line
+    second line
+  com.example.priority: '1'
+  com.example.team: platform
+dependencies:
+  - namespace: com.example
+    name: lib
+    type: go
+    version: v1.0.0
+  - namespace: com.example
+    name: app
+    type: go
+    version: v2.0.0
+    direction: DOWNSTREAM
+`
+
+func TestWrite(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, product(), &v1.Module{Namespace: "com.example", Name: "other", Type: "go", Version: &v1.ModuleVersion{Name: "1.0"}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := productManifest + `---
+namespace: com.example
+name: other
+type: go
+version:
+  name: '1.0'
+`
+	if b.String() != want {
+		t.Errorf("Write() = %s, want %s", b.String(), want)
+	}
+}
+
+func TestRead(t *testing.T) {
+	empty := ""
+	tests := []struct {
+		name string
+		data string
+		want []*v1.Module
+	}{
+		{"is canonical", productManifest, []*v1.Module{product()}},
+		{"is hand-written", `# the product
+namespace: com.example
+name: "product"
+type: go
+version: {name: v1.1.0, schema: semver, replaces: [v1.0.0]}
+annotations:
+  com.example.team: platform # owning team
+  com.example.priority: 1
+  com.example.description: "first line\nsecond line\n"
+dependencies:
+- {namespace: com.example, name: lib, type: go, version: v1.0.0}
+- namespace: com.example
+  name: app
+  type: go
+  version: v2.0.0
+  direction: 1
+`, []*v1.Module{product()}},
+		{"has multiple documents", "---\nname: a\n---\n---\nname: b\n", []*v1.Module{{Name: "a"}, {Name: "b"}}},
+		{"has explicit empty optional fields", "version:\n  schema: ''\ndependencies:\n  - direction: UPSTREAM\n", []*v1.Module{{
+			Version:      &v1.ModuleVersion{Schema: &empty},
+			Dependencies: []*v1.ModuleDependency{{Direction: v1.DependencyDirection_UPSTREAM.Enum()}},
+		}}},
+		{"has null fields", "name:\nversion:\n  schema: ~\nannotations: null\n", []*v1.Module{{Version: &v1.ModuleVersion{}}}},
+		{"is empty", "# nothing yet\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Read() returned %d modules, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !proto.Equal(got[i], tt.want[i]) {
+					t.Errorf("Read()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRead_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"has syntax error", "name: a\n  type: b\n", "manifest: line 2, column 3: mapping values are not allowed here"},
+		{"has unknown field", "name: a\nowner: me\n", `manifest: line 2, column 1: unknown field "owner"`},
+		{"has unknown nested field", "version:\n  name: v1\n  date: today\n", `manifest: line 3, column 3: version: unknown field "date"`},
+		{"has unknown dependency field", "dependencies:\n  - name: a\n  - scope: test\n", `manifest: line 3, column 5: dependencies[1]: unknown field "scope"`},
+		{"has mapping as string", "name:\n  first: a\n", "manifest: line 2, column 3: name: must be a string"},
+		{"has string as mapping", "version: v1.0.0\n", "manifest: line 1, column 10: version: must be a mapping"},
+		{"has string as list", "version:\n  replaces: v1.0.0\n", "manifest: line 2, column 13: version.replaces: must be a list"},
+		{"has unknown direction", "dependencies:\n  - direction: SIDEWAYS\n", `manifest: line 2, column 16: dependencies[0].direction: unknown dependency direction "SIDEWAYS", must be UPSTREAM or DOWNSTREAM`},
+		{"has null dependency", "dependencies:\n  -\n", "manifest: line 2, column 4: dependencies[0]: must be a mapping"},
+		{"has scalar document", "---\nname: a\n---\njust text\n", "manifest: line 4, column 1: must be a mapping"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.data))
+			var manifestErr *Error
+			if !errors.As(err, &manifestErr) {
+				t.Fatalf("Read() error = %v, want *Error", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Read() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFile_Write(t *testing.T) {
+	data := `# Manifest of the product.
+
+namespace: com.example # the company
+name: product
+type: go
+version:
+  # bumped for the new API
+  name: v1.0.0
+dependencies:
+  # the shared library
+  - {namespace: com.example, name: lib, type: go, version: v1.0.0}
+  - namespace: com.example # owned by us
+    name: util
+    type: go
+    version: v0.1.0
+# end of product
+---
+# another module
+name: other
+---
+# trailing comment
+`
+	f, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	f.Modules[0].Version.Name = "v1.1.0"
+	f.Modules[0].Dependencies = f.Modules[0].Dependencies[:1]
+	f.Modules[1].Type = "go"
+	f.Modules = append(f.Modules, &v1.Module{Name: "added"})
+
+	want := `# Manifest of the product.
+
+namespace: com.example # the company
+name: product
+type: go
+version:
+  # bumped for the new API
+  name: v1.1.0
+dependencies:
+  # the shared library
+  - namespace: com.example
+    name: lib
+    type: go
+    version: v1.0.0
+# end of product
+---
+# another module
+name: other
+type: go
+---
+# trailing comment
+---
+name: added
+`
+	var b bytes.Buffer
+	if err := f.Write(&b); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if b.String() != want {
+		t.Errorf("Write() = %s, want %s", b.String(), want)
+	}
+}
+
+func TestWrite_roundTrip(t *testing.T) {
+	schema := ""
+	modules := []*v1.Module{
+		product(),
+		{},
+		{Version: &v1.ModuleVersion{}},
+		{Version: &v1.ModuleVersion{Schema: &schema}},
+		{Annotations: map[string]string{"empty": "", "null": "null", "quote": "it's \"quoted\"", "tab": "a\tb", "lines": "a\n\nb\n\n"}},
+		{Dependencies: []*v1.ModuleDependency{{}, {Direction: v1.DependencyDirection_UPSTREAM.Enum()}, {Direction: v1.DependencyDirection(7).Enum()}}},
+	}
+	for _, module := range modules {
+		var b bytes.Buffer
+		if err := Write(&b, module); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		got, err := Read(&b)
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		if len(got) != 1 || !proto.Equal(got[0], module) {
+			t.Errorf("round trip of %v = %v", module, got)
+		}
+	}
+}
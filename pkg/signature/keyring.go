@@ -0,0 +1,84 @@
+package signature
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Keyring is a set of trusted public keys, each authorized to sign modules of certain namespaces.
+type Keyring struct {
+	keys map[string]*trustedKey
+}
+
+type trustedKey struct {
+	publicKey  crypto.PublicKey
+	namespaces []string
+}
+
+// NewKeyring returns an empty keyring.
+func NewKeyring() *Keyring {
+	return &Keyring{keys: map[string]*trustedKey{}}
+}
+
+// Add trusts the ed25519 or ECDSA P-256 public key under the key ID to sign modules of the namespaces.
+// A namespace ending with .* authorizes all namespaces below it, e.g. com.example.* authorizes
+// com.example.team but not com.example itself.
+func (k *Keyring) Add(keyID string, publicKey crypto.PublicKey, namespaces ...string) error {
+	if keyID == "" {
+		return errors.New("key ID must be set")
+	}
+	if _, exists := k.keys[keyID]; exists {
+		return fmt.Errorf("key %q: already added", keyID)
+	}
+	if err := checkPublicKey(publicKey); err != nil {
+		return fmt.Errorf("key %q: %w", keyID, err)
+	}
+	if len(namespaces) == 0 {
+		return fmt.Errorf("key %q: at least one namespace must be given", keyID)
+	}
+	for _, namespace := range namespaces {
+		if strings.TrimSuffix(namespace, ".*") == "" || strings.Contains(strings.TrimSuffix(namespace, ".*"), "*") {
+			return fmt.Errorf("key %q: namespace %q must be a namespace, optionally followed by .*", keyID, namespace)
+		}
+	}
+
+	k.keys[keyID] = &trustedKey{publicKey: publicKey, namespaces: append([]string(nil), namespaces...)}
+	return nil
+}
+
+// KeyIDs returns the sorted IDs of all keys.
+func (k *Keyring) KeyIDs() []string {
+	keyIDs := make([]string, 0, len(k.keys))
+	for keyID := range k.keys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	return keyIDs
+}
+
+// Authorized returns the sorted IDs of all keys authorized to sign modules of the namespace.
+func (k *Keyring) Authorized(namespace string) []string {
+	var keyIDs []string
+	for _, keyID := range k.KeyIDs() {
+		if k.keys[keyID].authorizes(namespace) {
+			keyIDs = append(keyIDs, keyID)
+		}
+	}
+	return keyIDs
+}
+
+func (t *trustedKey) authorizes(namespace string) bool {
+	for _, pattern := range t.namespaces {
+		if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+			if strings.HasPrefix(namespace, prefix) && len(namespace) > len(prefix) {
+				return true
+			}
+		} else if namespace == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"reflect"
+	"testing"
+)
+
+func TestKeyring_Add(t *testing.T) {
+	key := ed25519Key(t).Public()
+	tests := []struct {
+		name       string
+		keyID      string
+		publicKey  crypto.PublicKey
+		namespaces []string
+		wantErr    bool
+	}{
+		{"has namespace", "other", key, []string{"com.example"}, false},
+		{"has namespace pattern", "pattern", ecdsaKey(t, elliptic.P256()).Public(), []string{"com.example.*", "org.example"}, false},
+
+		{"has no key ID", "", key, []string{"com.example"}, true},
+		{"has duplicate key ID", "existing", key, []string{"com.example"}, true},
+		{"has private key", "private", ed25519Key(t), []string{"com.example"}, true},
+		{"has ECDSA P-384 key", "p384", ecdsaKey(t, elliptic.P384()).Public(), []string{"com.example"}, true},
+		{"has nil ECDSA key", "nil", (*ecdsa.PublicKey)(nil), []string{"com.example"}, true},
+		{"has nil public key", "nil-interface", nil, []string{"com.example"}, true},
+		{"has invalid ed25519 key", "short", key.(ed25519.PublicKey)[:16], []string{"com.example"}, true},
+		{"has no namespaces", "none", key, nil, true},
+		{"has empty namespace", "empty", key, []string{""}, true},
+		{"has only wildcard", "wildcard", key, []string{"*"}, true},
+		{"has inner wildcard", "inner", key, []string{"com.*.team"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := NewKeyring()
+			if err := k.Add("existing", key, "com.example"); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+			if err := k.Add(tt.keyID, tt.publicKey, tt.namespaces...); (err != nil) != tt.wantErr {
+				t.Errorf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyring_Authorized(t *testing.T) {
+	k := NewKeyring()
+	for keyID, namespaces := range map[string][]string{
+		"example":   {"com.example"},
+		"teams":     {"com.example.*"},
+		"both":      {"com.example", "com.example.*"},
+		"other":     {"org.other"},
+		"lookalike": {"com.examples"},
+	} {
+		if err := k.Add(keyID, ed25519Key(t).Public(), namespaces...); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if got, want := k.KeyIDs(), []string{"both", "example", "lookalike", "other", "teams"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("KeyIDs() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		namespace string
+		want      []string
+	}{
+		{"com.example", []string{"both", "example"}},
+		{"com.example.team", []string{"both", "teams"}},
+		{"com.example.team.sub", []string{"both", "teams"}},
+		{"com.examples", []string{"lookalike"}},
+		{"com.exampleteam", nil},
+		{"com", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			if got := k.Authorized(tt.namespace); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Authorized() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
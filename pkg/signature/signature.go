@@ -0,0 +1,168 @@
+// Package signature signs and verifies modules with detached signatures,
+// see https://github.com/secure-systems-lab/dsse/blob/master/envelope.md.
+//
+// A module is signed by signing its canonical digest, see v1.Module.DigestWithOptions.
+// The signatures are stored in a DSSE envelope next to the module, whose payload is the digest
+// in the form algorithm:hex. Supported keys are ed25519 and ECDSA P-256 keys.
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// PayloadType is the payload type of envelopes holding a module digest.
+const PayloadType = "application/vnd.opendependency.module.digest"
+
+// Envelope is a DSSE envelope holding the signatures of a module digest.
+type Envelope struct {
+	// PayloadType identifies the payload, it is always PayloadType.
+	PayloadType string `json:"payloadType"`
+	// Payload is the signed module digest in the form algorithm:hex.
+	Payload []byte `json:"payload"`
+	// Signatures are the signatures over the payload.
+	Signatures []Signature `json:"signatures"`
+}
+
+// Signature is a signature over the payload of an envelope.
+type Signature struct {
+	// KeyID identifies the key used for the signature. It is a hint and not signed.
+	KeyID string `json:"keyid,omitempty"`
+	// Sig is the signature over the pre-authentication encoding of the payload.
+	Sig []byte `json:"sig"`
+}
+
+// ParseEnvelope parses an envelope from its JSON representation.
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("envelope: %w", err)
+	}
+	if envelope.PayloadType != PayloadType {
+		return nil, fmt.Errorf("envelope: payload type %q is not supported, must be %q", envelope.PayloadType, PayloadType)
+	}
+	if len(envelope.Signatures) == 0 {
+		return nil, errors.New("envelope: must contain at least one signature")
+	}
+	return &envelope, nil
+}
+
+// Signer signs module digests with a private key.
+type Signer struct {
+	keyID string
+	key   crypto.Signer
+}
+
+// NewSigner returns a signer for the ed25519 or ECDSA P-256 private key,
+// which identifies its signatures by the key ID.
+func NewSigner(keyID string, key crypto.Signer) (*Signer, error) {
+	if key == nil {
+		return nil, errors.New("key must not be nil")
+	}
+	if err := checkPublicKey(key.Public()); err != nil {
+		return nil, err
+	}
+	return &Signer{keyID: keyID, key: key}, nil
+}
+
+// KeyID returns the key ID of the signer.
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// Sign signs the SHA-256 digest of the module by all signers.
+func Sign(module *v1.Module, signers ...*Signer) (*Envelope, error) {
+	if module == nil {
+		return nil, errors.New("module must not be nil")
+	}
+	if len(signers) == 0 {
+		return nil, errors.New("at least one signer must be given")
+	}
+
+	envelope := &Envelope{
+		PayloadType: PayloadType,
+		Payload:     []byte(module.Digest().String()),
+	}
+	for _, signer := range signers {
+		if err := signer.AddSignature(envelope); err != nil {
+			return nil, err
+		}
+	}
+	return envelope, nil
+}
+
+// AddSignature adds the signature of the signer to the envelope, e.g. to co-sign a module.
+func (s *Signer) AddSignature(envelope *Envelope) error {
+	sig, err := s.sign(pae(envelope.PayloadType, envelope.Payload))
+	if err != nil {
+		return fmt.Errorf("sign with key %q: %w", s.keyID, err)
+	}
+	envelope.Signatures = append(envelope.Signatures, Signature{KeyID: s.keyID, Sig: sig})
+	return nil
+}
+
+func (s *Signer) sign(message []byte) ([]byte, error) {
+	switch s.key.Public().(type) {
+	case ed25519.PublicKey:
+		return s.key.Sign(rand.Reader, message, crypto.Hash(0))
+	default:
+		hashed := sha256.Sum256(message)
+		return s.key.Sign(rand.Reader, hashed[:], crypto.SHA256)
+	}
+}
+
+// verify reports whether sig is a valid signature of the message by the public key.
+func verify(publicKey crypto.PublicKey, message []byte, sig []byte) bool {
+	switch publicKey := publicKey.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(publicKey, message, sig)
+	case *ecdsa.PublicKey:
+		hashed := sha256.Sum256(message)
+		return ecdsa.VerifyASN1(publicKey, hashed[:], sig)
+	default:
+		return false
+	}
+}
+
+// checkPublicKey returns an error if the public key is neither an ed25519 nor an ECDSA P-256 key.
+func checkPublicKey(publicKey crypto.PublicKey) error {
+	switch publicKey := publicKey.(type) {
+	case ed25519.PublicKey:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("ed25519 public key must have %d bytes", ed25519.PublicKeySize)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if publicKey == nil {
+			return errors.New("ECDSA public key must not be nil")
+		}
+		if publicKey.Curve != elliptic.P256() {
+			return errors.New("ECDSA key must use the P-256 curve")
+		}
+		return nil
+	default:
+		return fmt.Errorf("key type %T is not supported, must be ed25519 or ECDSA P-256", publicKey)
+	}
+}
+
+// pae returns the DSSE pre-authentication encoding of the payload.
+func pae(payloadType string, payload []byte) []byte {
+	b := []byte("DSSEv1 ")
+	b = strconv.AppendInt(b, int64(len(payloadType)), 10)
+	b = append(b, ' ')
+	b = append(b, payloadType...)
+	b = append(b, ' ')
+	b = strconv.AppendInt(b, int64(len(payload)), 10)
+	b = append(b, ' ')
+	return append(b, payload...)
+}
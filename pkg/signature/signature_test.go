@@ -0,0 +1,161 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func module() *v1.Module {
+	return &v1.Module{
+		Namespace: "com.example",
+		Name:      "product",
+		Type:      "go",
+		Version:   &v1.ModuleVersion{Name: "v1.0.0"},
+		Dependencies: []*v1.ModuleDependency{
+			{Namespace: "org.other", Name: "lib", Type: "go", Version: "v0.1.0"},
+		},
+	}
+}
+
+func ed25519Key(t *testing.T) ed25519.PrivateKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return key
+}
+
+func ecdsaKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return key
+}
+
+func newSigner(t *testing.T, keyID string, key crypto.Signer) *Signer {
+	signer, err := NewSigner(keyID, key)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	return signer
+}
+
+func TestNewSigner(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		key     crypto.Signer
+		wantErr bool
+	}{
+		{"has ed25519 key", ed25519Key(t), false},
+		{"has ECDSA P-256 key", ecdsaKey(t, elliptic.P256()), false},
+		{"has ECDSA P-384 key", ecdsaKey(t, elliptic.P384()), true},
+		{"has RSA key", rsaKey, true},
+		{"has no key", nil, true},
+		{"has nil ECDSA public key", nilPublicKeySigner{}, true},
+		{"has ECDSA key without curve", &ecdsa.PrivateKey{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSigner("key", tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSigner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// nilPublicKeySigner is a crypto.Signer returning a nil ECDSA public key.
+type nilPublicKeySigner struct{}
+
+func (nilPublicKeySigner) Public() crypto.PublicKey {
+	return (*ecdsa.PublicKey)(nil)
+}
+
+func (nilPublicKeySigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, nil
+}
+
+func TestSign(t *testing.T) {
+	ed := newSigner(t, "ed", ed25519Key(t))
+	ec := newSigner(t, "ec", ecdsaKey(t, elliptic.P256()))
+
+	envelope, err := Sign(module(), ed, ec)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if envelope.PayloadType != PayloadType {
+		t.Errorf("Sign() payload type = %v, want %v", envelope.PayloadType, PayloadType)
+	}
+	if want := module().Digest().String(); string(envelope.Payload) != want {
+		t.Errorf("Sign() payload = %s, want %s", envelope.Payload, want)
+	}
+	if len(envelope.Signatures) != 2 || envelope.Signatures[0].KeyID != "ed" || envelope.Signatures[1].KeyID != "ec" {
+		t.Errorf("Sign() signatures = %v, want signatures of ed and ec", envelope.Signatures)
+	}
+
+	if _, err := Sign(nil, ed); err == nil {
+		t.Errorf("Sign() of nil module did not fail")
+	}
+	if _, err := Sign(module()); err == nil {
+		t.Errorf("Sign() without signers did not fail")
+	}
+}
+
+func TestParseEnvelope(t *testing.T) {
+	envelope, err := Sign(module(), newSigner(t, "ed", ed25519Key(t)))
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    *Envelope
+		wantErr bool
+	}{
+		{"is signed envelope", string(data), envelope, false},
+		{"has other payload type", `{"payloadType": "application/json", "payload": "", "signatures": [{"sig": ""}]}`, nil, true},
+		{"has no signatures", `{"payloadType": "` + PayloadType + `", "payload": "", "signatures": []}`, nil, true},
+		{"has invalid base64", `{"payloadType": "` + PayloadType + `", "payload": "!", "signatures": [{"sig": ""}]}`, nil, true},
+		{"is no JSON", `payload`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEnvelope([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEnvelope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEnvelope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pae(t *testing.T) {
+	// test vector of the DSSE specification
+	got := string(pae("http://example.com/HelloWorld", []byte("hello world")))
+	if want := "DSSEv1 29 http://example.com/HelloWorld 11 hello world"; got != want {
+		t.Errorf("pae() = %q, want %q", got, want)
+	}
+}
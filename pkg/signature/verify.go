@@ -0,0 +1,59 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Verify verifies that the envelope holds a valid signature of the module by a key of the keyring,
+// which is authorized to sign modules of the module's namespace. It returns the ID of that key.
+//
+// Signatures with a key ID are only checked against the key with that ID,
+// signatures without key ID against all authorized keys.
+func Verify(module *v1.Module, envelope *Envelope, keyring *Keyring) (string, error) {
+	if module == nil {
+		return "", errors.New("module must not be nil")
+	}
+	if envelope == nil {
+		return "", errors.New("envelope must not be nil")
+	}
+	if keyring == nil {
+		return "", errors.New("keyring must not be nil")
+	}
+	if envelope.PayloadType != PayloadType {
+		return "", fmt.Errorf("envelope: payload type %q is not supported, must be %q", envelope.PayloadType, PayloadType)
+	}
+
+	signed, err := v1.ParseDigest(string(envelope.Payload))
+	if err != nil {
+		return "", fmt.Errorf("envelope: payload: %w", err)
+	}
+	actual, err := module.DigestWithOptions(v1.DigestOptions{Algorithm: signed.Algorithm})
+	if err != nil {
+		return "", fmt.Errorf("envelope: payload: %w", err)
+	}
+	if !actual.Equal(signed) {
+		return "", fmt.Errorf("module digest %s does not match signed digest %s", actual, signed)
+	}
+
+	namespace := module.GetNamespace()
+	keyIDs := keyring.Authorized(namespace)
+	if len(keyIDs) == 0 {
+		return "", fmt.Errorf("no trusted key is authorized to sign modules of namespace %q", namespace)
+	}
+
+	message := pae(envelope.PayloadType, envelope.Payload)
+	for _, signature := range envelope.Signatures {
+		for _, keyID := range keyIDs {
+			if signature.KeyID != "" && signature.KeyID != keyID {
+				continue
+			}
+			if verify(keyring.keys[keyID].publicKey, message, signature.Sig) {
+				return keyID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no valid signature by a key authorized to sign modules of namespace %q", namespace)
+}
@@ -0,0 +1,152 @@
+package signature
+
+import (
+	"crypto/elliptic"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func TestVerify(t *testing.T) {
+	owner := ed25519Key(t)
+	team := ecdsaKey(t, elliptic.P256())
+	other := ed25519Key(t)
+	untrusted := ed25519Key(t)
+
+	keyring := NewKeyring()
+	if err := keyring.Add("owner", owner.Public(), "com.example"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := keyring.Add("team", team.Public(), "com.example.*"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := keyring.Add("other", other.Public(), "org.other"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		module    func() *v1.Module
+		signers   []*Signer
+		modify    func(e *Envelope)
+		tamper    func(m *v1.Module)
+		wantKeyID string
+		wantErr   string
+	}{
+		{"is signed by owner", module, []*Signer{newSigner(t, "owner", owner)}, nil, nil, "owner", ""},
+		{"is signed by ECDSA key of sub-namespace", func() *v1.Module {
+			m := module()
+			m.Namespace = "com.example.team"
+			return m
+		}, []*Signer{newSigner(t, "team", team)}, nil, nil, "team", ""},
+		{"is co-signed by untrusted key and owner", module, []*Signer{newSigner(t, "untrusted", untrusted), newSigner(t, "owner", owner)}, nil, nil, "owner", ""},
+		{"is signed without key ID", module, []*Signer{newSigner(t, "", owner)}, nil, nil, "owner", ""},
+		{"has reordered dependencies", func() *v1.Module {
+			m := module()
+			m.Dependencies = append(m.Dependencies, &v1.ModuleDependency{Name: "last"})
+			return m
+		}, []*Signer{newSigner(t, "owner", owner)}, nil, func(m *v1.Module) {
+			m.Dependencies[0], m.Dependencies[1] = m.Dependencies[1], m.Dependencies[0]
+		}, "owner", ""},
+		{"has SHA-512 digest", module, []*Signer{newSigner(t, "owner", owner)}, func(e *Envelope) {
+			d, err := module().DigestWithOptions(v1.DigestOptions{Algorithm: v1.DigestAlgorithmSHA512})
+			if err != nil {
+				t.Fatalf("DigestWithOptions() error = %v", err)
+			}
+			e.Payload = []byte(d.String())
+			e.Signatures = nil
+			if err := newSigner(t, "owner", owner).AddSignature(e); err != nil {
+				t.Fatalf("AddSignature() error = %v", err)
+			}
+		}, nil, "owner", ""},
+
+		{"is signed by key of other namespace", module, []*Signer{newSigner(t, "other", other)}, nil, nil, "", `no valid signature by a key authorized to sign modules of namespace "com.example"`},
+		{"is signed by untrusted key with trusted key ID", module, []*Signer{newSigner(t, "owner", untrusted)}, nil, nil, "", `no valid signature by a key authorized to sign modules of namespace "com.example"`},
+		{"is signed by owner with other key ID", module, []*Signer{newSigner(t, "team", owner)}, nil, nil, "", `no valid signature by a key authorized to sign modules of namespace "com.example"`},
+		{"has namespace without trusted key", func() *v1.Module {
+			m := module()
+			m.Namespace = "net.unknown"
+			return m
+		}, []*Signer{newSigner(t, "owner", owner)}, nil, nil, "", `no trusted key is authorized to sign modules of namespace "net.unknown"`},
+		{"has tampered module", module, []*Signer{newSigner(t, "owner", owner)}, nil, func(m *v1.Module) {
+			m.Dependencies[0].Version = "v0.2.0"
+		}, "", "module digest sha256:"},
+		{"has tampered module and payload", module, []*Signer{newSigner(t, "owner", owner)}, func(e *Envelope) {
+			m := module()
+			m.Dependencies[0].Version = "v0.2.0"
+			e.Payload = []byte(m.Digest().String())
+		}, func(m *v1.Module) {
+			m.Dependencies[0].Version = "v0.2.0"
+		}, "", `no valid signature by a key authorized to sign modules of namespace "com.example"`},
+		{"has tampered signature", module, []*Signer{newSigner(t, "owner", owner)}, func(e *Envelope) {
+			e.Signatures[0].Sig[0] ^= 0xff
+		}, nil, "", `no valid signature by a key authorized to sign modules of namespace "com.example"`},
+		{"has other payload type", module, []*Signer{newSigner(t, "owner", owner)}, func(e *Envelope) {
+			e.PayloadType = "application/json"
+		}, nil, "", `envelope: payload type "application/json" is not supported`},
+		{"has invalid payload", module, []*Signer{newSigner(t, "owner", owner)}, func(e *Envelope) {
+			e.Payload = []byte("sha256")
+		}, nil, "", "envelope: payload: digest \"sha256\": must have the form algorithm:hex"},
+		{"has unknown digest algorithm", module, []*Signer{newSigner(t, "owner", owner)}, func(e *Envelope) {
+			e.Payload = []byte("md5:00ff")
+		}, nil, "", `envelope: payload: unknown digest algorithm "md5"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := Sign(tt.module(), tt.signers...)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			if tt.modify != nil {
+				tt.modify(envelope)
+			}
+
+			// verify the envelope as it would be read from a file
+			data, err := json.Marshal(envelope)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var decoded Envelope
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			m := tt.module()
+			if tt.tamper != nil {
+				tt.tamper(m)
+			}
+			keyID, err := Verify(m, &decoded, keyring)
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if keyID != tt.wantKeyID {
+				t.Errorf("Verify() = %v, want %v", keyID, tt.wantKeyID)
+			}
+		})
+	}
+}
+
+func TestVerify_nil(t *testing.T) {
+	envelope, err := Sign(module(), newSigner(t, "owner", ed25519Key(t)))
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	if _, err := Verify(nil, envelope, NewKeyring()); err == nil {
+		t.Errorf("Verify() of nil module did not fail")
+	}
+	if _, err := Verify(module(), nil, NewKeyring()); err == nil {
+		t.Errorf("Verify() of nil envelope did not fail")
+	}
+	if _, err := Verify(module(), envelope, nil); err == nil {
+		t.Errorf("Verify() with nil keyring did not fail")
+	}
+}
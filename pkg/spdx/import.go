@@ -0,0 +1,244 @@
+package spdx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opendependency/go-spec/pkg/importer"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// dependencyOfTypes lists the relationship types, which are imported like DEPENDENCY_OF.
+var dependencyOfTypes = map[string]bool{
+	"BUILD_DEPENDENCY_OF":    true,
+	"DEV_DEPENDENCY_OF":      true,
+	"OPTIONAL_DEPENDENCY_OF": true,
+	"PROVIDED_DEPENDENCY_OF": true,
+	"RUNTIME_DEPENDENCY_OF":  true,
+	"TEST_DEPENDENCY_OF":     true,
+}
+
+// ReadJSON parses an SPDX JSON document and converts it into modules, see Document.Modules.
+func ReadJSON(r io.Reader) ([]*v1.Module, *importer.Report, error) {
+	var d Document
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return nil, nil, fmt.Errorf("spdx: %w", err)
+	}
+	return d.Modules()
+}
+
+// Modules converts the document into modules.
+//
+// Every package becomes a module, whereas its coordinate is derived from the purl external reference
+// or alternatively from name and version. DEPENDS_ON relationships become UPSTREAM dependencies and
+// DEPENDENCY_OF relationships DOWNSTREAM dependencies. Specialized relationships like DEV_DEPENDENCY_OF
+// are imported like DEPENDENCY_OF. Identifiers are normalized to satisfy the specification constraints and
+// all lossy transformations are recorded in the returned report, including document and package members
+// without module equivalent like files and licenses and relationships of other types.
+func (d *Document) Modules() ([]*v1.Module, *importer.Report, error) {
+	if !strings.HasPrefix(d.SPDXVersion, "SPDX-2.") {
+		return nil, nil, fmt.Errorf("spdx: spdxVersion %q is not supported", d.SPDXVersion)
+	}
+
+	i := documentImport{
+		report:  &importer.Report{},
+		modules: make(map[v1.Coordinate]*v1.Module),
+		ids:     make(map[string]*v1.Module),
+	}
+
+	source := d.SPDXID
+	if source == "" {
+		source = DocumentID
+	}
+	for _, field := range d.unsupported {
+		i.report.Drop(source, "field", field, "not supported")
+	}
+	for _, field := range d.CreationInfo.unsupported {
+		i.report.Drop(source, "field", "creationInfo."+field, "not supported")
+	}
+
+	for _, p := range d.Packages {
+		i.addPackage(p)
+	}
+	for j, r := range d.Relationships {
+		if j < len(d.unsupportedRelationships) {
+			for _, field := range d.unsupportedRelationships[j] {
+				i.report.Drop(r.SPDXElementID, "field", "relationships."+field, "not supported")
+			}
+		}
+		i.addRelationship(r)
+	}
+
+	return i.result, i.report, nil
+}
+
+// UnmarshalJSON decodes the document and remembers unsupported members, which are reported by Document.Modules.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	type document Document
+	if err := json.Unmarshal(data, (*document)(d)); err != nil {
+		return err
+	}
+
+	unsupported, err := importer.UnknownFields(data, d)
+	if err != nil {
+		return err
+	}
+	d.unsupported = unsupported
+
+	var elements struct {
+		Relationships []json.RawMessage `json:"relationships"`
+	}
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	d.unsupportedRelationships, err = unknownElementFields(elements.Relationships, Relationship{})
+	return err
+}
+
+// UnmarshalJSON decodes the creation info and remembers unsupported members, which are reported by Document.Modules.
+func (c *CreationInfo) UnmarshalJSON(data []byte) error {
+	type creationInfo CreationInfo
+	if err := json.Unmarshal(data, (*creationInfo)(c)); err != nil {
+		return err
+	}
+
+	unsupported, err := importer.UnknownFields(data, c)
+	if err != nil {
+		return err
+	}
+	c.unsupported = unsupported
+	return nil
+}
+
+// UnmarshalJSON decodes the package and remembers unsupported members, which are reported by Document.Modules.
+func (p *Package) UnmarshalJSON(data []byte) error {
+	type package_ Package
+	if err := json.Unmarshal(data, (*package_)(p)); err != nil {
+		return err
+	}
+
+	unsupported, err := importer.UnknownFields(data, p)
+	if err != nil {
+		return err
+	}
+	p.unsupported = unsupported
+
+	var elements struct {
+		ExternalRefs []json.RawMessage `json:"externalRefs"`
+	}
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	p.unsupportedExternalRefs, err = unknownElementFields(elements.ExternalRefs, ExternalRef{})
+	return err
+}
+
+// unknownElementFields returns the unsupported members of each JSON object of an array decoded into v,
+// see importer.UnknownFields, or nil if all members are supported.
+func unknownElementFields(elements []json.RawMessage, v interface{}) ([][]string, error) {
+	var result [][]string
+	for i, element := range elements {
+		unsupported, err := importer.UnknownFields(element, v)
+		if err != nil {
+			return nil, err
+		}
+		if len(unsupported) > 0 {
+			if result == nil {
+				result = make([][]string, len(elements))
+			}
+			result[i] = unsupported
+		}
+	}
+	return result, nil
+}
+
+// documentImport holds the state of a document conversion.
+type documentImport struct {
+	report  *importer.Report
+	result  []*v1.Module
+	modules map[v1.Coordinate]*v1.Module
+	ids     map[string]*v1.Module
+}
+
+func (i *documentImport) addPackage(p Package) {
+	source := p.SPDXID
+	for _, field := range p.unsupported {
+		i.report.Drop(source, "field", field, "not supported")
+	}
+	if p.DownloadLocation != "" && p.DownloadLocation != NoAssertion && p.DownloadLocation != None {
+		i.report.Drop(source, "downloadLocation", p.DownloadLocation, "not supported")
+	}
+
+	var purl string
+	for j, ref := range p.ExternalRefs {
+		if j < len(p.unsupportedExternalRefs) {
+			for _, field := range p.unsupportedExternalRefs[j] {
+				i.report.Drop(source, "field", "externalRefs."+field, "not supported")
+			}
+		}
+		if ref.ReferenceType == ReferenceTypePURL && purl == "" {
+			purl = ref.ReferenceLocator
+			continue
+		}
+		i.report.Drop(source, "externalRef", ref.ReferenceType+" "+ref.ReferenceLocator, "not supported")
+	}
+
+	coordinate, ok := i.report.Coordinate(source, purl, v1.Coordinate{Name: p.Name, Version: p.VersionInfo})
+	if !ok {
+		i.report.Drop(source, "package", p.Name, "no valid name can be derived")
+		return
+	}
+
+	if module, exists := i.modules[coordinate]; exists {
+		i.report.Drop(source, "package", coordinate.String(), "duplicate module")
+		i.ids[p.SPDXID] = module
+		return
+	}
+
+	module := coordinate.Module()
+	i.modules[coordinate] = module
+	i.result = append(i.result, module)
+	i.ids[p.SPDXID] = module
+}
+
+func (i *documentImport) addRelationship(r Relationship) {
+	// every package becomes a module, so that it does not matter which packages the document describes
+	if r.RelationshipType == RelationshipDescribes {
+		return
+	}
+
+	relationship := r.SPDXElementID + " " + r.RelationshipType + " " + r.RelatedSPDXElement
+
+	var direction v1.DependencyDirection
+	switch {
+	case r.RelationshipType == RelationshipDependsOn:
+		direction = v1.DependencyDirection_UPSTREAM
+	case r.RelationshipType == RelationshipDependencyOf:
+		direction = v1.DependencyDirection_DOWNSTREAM
+	case dependencyOfTypes[r.RelationshipType]:
+		i.report.Add(r.SPDXElementID, "relationship", relationship, r.SPDXElementID+" "+RelationshipDependencyOf+" "+r.RelatedSPDXElement, "dependency scopes cannot be represented")
+		direction = v1.DependencyDirection_DOWNSTREAM
+	default:
+		i.report.Drop(r.SPDXElementID, "relationship", relationship, "not a dependency relationship")
+		return
+	}
+
+	module, ok := i.ids[r.SPDXElementID]
+	if !ok {
+		i.report.Drop(r.SPDXElementID, "relationship", relationship, "unknown SPDX element "+r.SPDXElementID)
+		return
+	}
+	target, ok := i.ids[r.RelatedSPDXElement]
+	if !ok {
+		i.report.Drop(r.SPDXElementID, "relationship", relationship, "unknown SPDX element "+r.RelatedSPDXElement)
+		return
+	}
+
+	dependency := target.Coordinate().Dependency()
+	if direction == v1.DependencyDirection_DOWNSTREAM {
+		dependency.Direction = direction.Enum()
+	}
+	importer.AddDependency(module, dependency)
+}
@@ -0,0 +1,187 @@
+package spdx
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func TestReadJSON_roundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, modules(), Options{Created: created}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	got, report, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if !report.Lossless() {
+		t.Errorf("ReadJSON() report =\n%s\nwant lossless", report)
+	}
+
+	want := map[string][]string{
+		"com.example/app:go@v1.0.0": {
+			"com.example/cli:go@v1.0.0 DOWNSTREAM",
+			"com.example/lib-core:go@v1.2.0 UPSTREAM",
+			"org.other/log.v2:go@v2.0.0 UPSTREAM",
+		},
+		"com.example/cli:go@v1.0.0":      nil,
+		"com.example/lib-core:go@v1.2.0": nil,
+		"org.other/log.v2:go@v2.0.0":     nil,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ReadJSON() = %d modules, want %d", len(got), len(want))
+	}
+	for _, m := range got {
+		wantDependencies, ok := want[m.Coordinate().String()]
+		if !ok {
+			t.Errorf("ReadJSON() unexpected module %v", m.Coordinate())
+			continue
+		}
+		if got := dependencies(m); strings.Join(got, ",") != strings.Join(wantDependencies, ",") {
+			t.Errorf("ReadJSON() %v dependencies = %v, want %v", m.Coordinate(), got, wantDependencies)
+		}
+	}
+}
+
+func TestReadJSON_normalization(t *testing.T) {
+	document := `{
+  "spdxVersion": "SPDX-2.2",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-app", "name": "My App", "versionInfo": "1.0", "downloadLocation": "https://example.com/app.tgz",
+      "licenseConcluded": "MIT"
+    },
+    {
+      "SPDXID": "SPDXRef-lib", "name": "lib", "downloadLocation": "NOASSERTION",
+      "externalRefs": [
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:lib:lib:1.0:*:*:*:*:*:*:*"},
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:maven/org.Example/lib@1.0"}
+      ]
+    },
+    {"SPDXID": "SPDXRef-test", "name": "test-helper", "versionInfo": "2.0"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-lib"},
+    {"spdxElementId": "SPDXRef-test", "relationshipType": "TEST_DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-app", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-lib"},
+    {"spdxElementId": "SPDXRef-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-missing"}
+  ]
+}`
+
+	got, report, err := ReadJSON(strings.NewReader(document))
+	if err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+
+	want := map[string][]string{
+		"generic/my-app:generic@1.0":      {"org.example/lib:maven@1.0 UPSTREAM"},
+		"org.example/lib:maven@1.0":       nil,
+		"generic/test-helper:generic@2.0": {"generic/my-app:generic@1.0 DOWNSTREAM"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ReadJSON() = %d modules, want %d", len(got), len(want))
+	}
+	for _, m := range got {
+		if err := m.ValidateAll(); err != nil {
+			t.Errorf("ReadJSON() module %v is invalid: %v", m.Coordinate(), err)
+		}
+		wantDependencies, ok := want[m.Coordinate().String()]
+		if !ok {
+			t.Errorf("ReadJSON() unexpected module %v", m.Coordinate())
+			continue
+		}
+		if got := dependencies(m); strings.Join(got, ",") != strings.Join(wantDependencies, ",") {
+			t.Errorf("ReadJSON() %v dependencies = %v, want %v", m.Coordinate(), got, wantDependencies)
+		}
+	}
+
+	wantReport := `SPDXRef-app: field "licenseConcluded" dropped: not supported
+SPDXRef-app: downloadLocation "https://example.com/app.tgz" dropped: not supported
+SPDXRef-app: type "" changed to "generic": must be set
+SPDXRef-app: namespace "" changed to "generic": must be set
+SPDXRef-app: name "My App" changed to "my-app": must be a lowercase alphanumeric identifier
+SPDXRef-lib: externalRef "cpe23Type cpe:2.3:a:lib:lib:1.0:*:*:*:*:*:*:*" dropped: not supported
+SPDXRef-lib: namespace "org.Example" changed to "org.example": must be a lowercase alphanumeric identifier
+SPDXRef-test: type "" changed to "generic": must be set
+SPDXRef-test: namespace "" changed to "generic": must be set
+SPDXRef-test: relationship "SPDXRef-test TEST_DEPENDENCY_OF SPDXRef-app" changed to "SPDXRef-test DEPENDENCY_OF SPDXRef-app": dependency scopes cannot be represented
+SPDXRef-app: relationship "SPDXRef-app CONTAINS SPDXRef-lib" dropped: not a dependency relationship
+SPDXRef-app: relationship "SPDXRef-app DEPENDS_ON SPDXRef-missing" dropped: unknown SPDX element SPDXRef-missing`
+	if got := report.String(); got != wantReport {
+		t.Errorf("ReadJSON() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func TestReadJSON_unsupportedMembers(t *testing.T) {
+	document := `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "comment": "generated",
+  "creationInfo": {"creators": ["Tool: test"], "created": "2024-01-01T00:00:00Z", "licenseListVersion": "3.21"},
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "app", "versionInfo": "1.0", "downloadLocation": "NOASSERTION", "externalRefs": [
+      {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/app@1.0", "comment": "derived"}
+    ]}
+  ],
+  "files": [
+    {"SPDXID": "SPDXRef-main", "fileName": "./main.go"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-app", "comment": "root"},
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-main"}
+  ]
+}`
+
+	modules, report, err := ReadJSON(strings.NewReader(document))
+	if err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if len(modules) != 1 {
+		t.Errorf("ReadJSON() = %d modules, want 1", len(modules))
+	}
+
+	wantReport := `SPDXRef-DOCUMENT: field "comment" dropped: not supported
+SPDXRef-DOCUMENT: field "files" dropped: not supported
+SPDXRef-DOCUMENT: field "creationInfo.licenseListVersion" dropped: not supported
+SPDXRef-app: field "externalRefs.comment" dropped: not supported
+SPDXRef-app: namespace "" changed to "npm": must be set
+SPDXRef-DOCUMENT: field "relationships.comment" dropped: not supported
+SPDXRef-DOCUMENT: relationship "SPDXRef-DOCUMENT CONTAINS SPDXRef-main" dropped: not a dependency relationship`
+	if got := report.String(); got != wantReport {
+		t.Errorf("ReadJSON() report =\n%s\nwant\n%s", got, wantReport)
+	}
+}
+
+func TestReadJSON_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+	}{
+		{"invalid JSON", `{`},
+		{"unsupported version", `{"spdxVersion": "SPDX-3.0"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ReadJSON(strings.NewReader(tt.document)); err == nil {
+				t.Errorf("ReadJSON() error = nil, want error")
+			}
+		})
+	}
+}
+
+// dependencies returns the dependencies of the module as "coordinate direction", sorted by coordinate.
+func dependencies(m *v1.Module) []string {
+	var result []string
+	for _, d := range m.GetDependencies() {
+		result = append(result, d.Coordinate().String()+" "+d.GetDirection().String())
+	}
+	sort.Strings(result)
+	return result
+}
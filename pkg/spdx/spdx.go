@@ -0,0 +1,270 @@
+// Package spdx converts modules into SPDX 2.3 documents and vice versa, see https://spdx.github.io/spdx-spec/v2.3/.
+//
+// Only the subset of the SPDX format required to represent modules is supported.
+package spdx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opendependency/go-spec/pkg/graph"
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+const (
+	// Version is the SPDX specification version produced by this package.
+	Version = "SPDX-2.3"
+	// DataLicense is the license of the SPDX document itself.
+	DataLicense = "CC0-1.0"
+	// DocumentID is the SPDX identifier of the document.
+	DocumentID = "SPDXRef-DOCUMENT"
+	// NoAssertion indicates that no statement is made about a field.
+	NoAssertion = "NOASSERTION"
+	// None indicates that a field has no value.
+	None = "NONE"
+
+	// ReferenceTypePURL is the type of external references by package URL.
+	ReferenceTypePURL = "purl"
+
+	// RelationshipDescribes relates the document to a module of the module set.
+	RelationshipDescribes = "DESCRIBES"
+	// RelationshipDependsOn relates a module to an UPSTREAM dependency.
+	RelationshipDependsOn = "DEPENDS_ON"
+	// RelationshipDependencyOf relates a module to a DOWNSTREAM dependency.
+	RelationshipDependencyOf = "DEPENDENCY_OF"
+
+	// DefaultDocumentName is the document name used if none is configured.
+	DefaultDocumentName = "dependencies"
+	// DefaultCreator is the creator used if none is configured.
+	DefaultCreator = "Tool: go-spec"
+)
+
+// Options configures the generated document.
+type Options struct {
+	// Name is the document name. It defaults to DefaultDocumentName.
+	Name string
+	// Namespace is the unique document namespace URI.
+	// It defaults to a URI derived from the document name and the packages.
+	Namespace string
+	// Creators lists the creators of the document. It defaults to DefaultCreator.
+	Creators []string
+	// Created is the creation time of the document. It defaults to the current time.
+	Created time.Time
+}
+
+// Document is an SPDX document.
+type Document struct {
+	SPDXVersion       string         `json:"spdxVersion"`
+	DataLicense       string         `json:"dataLicense"`
+	SPDXID            string         `json:"SPDXID"`
+	Name              string         `json:"name"`
+	DocumentNamespace string         `json:"documentNamespace"`
+	CreationInfo      CreationInfo   `json:"creationInfo"`
+	Packages          []Package      `json:"packages,omitempty"`
+	Relationships     []Relationship `json:"relationships,omitempty"`
+
+	// unsupported lists the JSON members without corresponding field.
+	unsupported []string
+	// unsupportedRelationships lists the JSON members without corresponding field of each relationship.
+	// They are kept here, so that relationships stay comparable.
+	unsupportedRelationships [][]string
+}
+
+// CreationInfo describes who created the document and when.
+type CreationInfo struct {
+	Creators []string `json:"creators"`
+	Created  string   `json:"created"`
+
+	// unsupported lists the JSON members without corresponding field.
+	unsupported []string
+}
+
+// Package is a software package described by the document.
+type Package struct {
+	SPDXID           string        `json:"SPDXID"`
+	Name             string        `json:"name"`
+	VersionInfo      string        `json:"versionInfo,omitempty"`
+	DownloadLocation string        `json:"downloadLocation"`
+	FilesAnalyzed    bool          `json:"filesAnalyzed"`
+	ExternalRefs     []ExternalRef `json:"externalRefs,omitempty"`
+
+	// unsupported lists the JSON members without corresponding field.
+	unsupported []string
+	// unsupportedExternalRefs lists the JSON members without corresponding field of each external reference.
+	// They are kept here, so that external references stay comparable.
+	unsupportedExternalRefs [][]string
+}
+
+// ExternalRef refers to a package outside of the document, e.g. by package URL.
+type ExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// Relationship relates two SPDX elements.
+type Relationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// FromModules converts the modules into an SPDX document.
+//
+// Every module and every module referenced by a dependency becomes a package, which is
+// identified by the deterministic SPDX identifier returned by ID and referenced by its package URL.
+// The document describes the given modules. UPSTREAM dependencies are related by DEPENDS_ON,
+// DOWNSTREAM dependencies by DEPENDENCY_OF.
+func FromModules(modules []*v1.Module, opts Options) (*Document, error) {
+	g, err := graph.New(modules)
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range modules {
+		if err := module.ValidateAll(); err != nil {
+			return nil, err
+		}
+	}
+
+	packages := make(map[string]Package)
+	var relationships []Relationship
+	for _, n := range g.Nodes() {
+		p := newPackage(n.Coordinate)
+		packages[p.SPDXID] = p
+		relationships = append(relationships, Relationship{
+			SPDXElementID:      DocumentID,
+			RelationshipType:   RelationshipDescribes,
+			RelatedSPDXElement: p.SPDXID,
+		})
+	}
+	for _, declaration := range g.Declarations() {
+		p := newPackage(declaration.Dependency.Coordinate())
+		packages[p.SPDXID] = p
+
+		relationshipType := RelationshipDependsOn
+		if declaration.Dependency.GetDirection() == v1.DependencyDirection_DOWNSTREAM {
+			relationshipType = RelationshipDependencyOf
+		}
+		relationships = append(relationships, Relationship{
+			SPDXElementID:      ID(declaration.Declarer),
+			RelationshipType:   relationshipType,
+			RelatedSPDXElement: p.SPDXID,
+		})
+	}
+
+	d := &Document{
+		SPDXVersion:  Version,
+		DataLicense:  DataLicense,
+		SPDXID:       DocumentID,
+		Name:         opts.Name,
+		CreationInfo: CreationInfo{Creators: opts.Creators},
+	}
+	if d.Name == "" {
+		d.Name = DefaultDocumentName
+	}
+	if len(d.CreationInfo.Creators) == 0 {
+		d.CreationInfo.Creators = []string{DefaultCreator}
+	}
+	created := opts.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+	d.CreationInfo.Created = created.UTC().Format(time.RFC3339)
+
+	ids := make([]string, 0, len(packages))
+	for id := range packages {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		d.Packages = append(d.Packages, packages[id])
+	}
+	d.Relationships = uniqueRelationships(relationships)
+
+	d.DocumentNamespace = opts.Namespace
+	if d.DocumentNamespace == "" {
+		d.DocumentNamespace = "https://opendependency.org/spdx/" + idPart(d.Name) + "-" + hash(strings.Join(ids, "\n"))
+	}
+
+	return d, nil
+}
+
+// WriteJSON converts the modules into an SPDX document and writes it as indented JSON.
+func WriteJSON(w io.Writer, modules []*v1.Module, opts Options) error {
+	d, err := FromModules(modules, opts)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(d)
+}
+
+// ID returns the deterministic SPDX identifier of the module identified by the coordinate.
+// It consists of the type, name and version, restricted to the characters allowed by SPDX,
+// followed by a hash of the coordinate, which keeps identifiers unique.
+func ID(coordinate v1.Coordinate) string {
+	return "SPDXRef-" + idPart(coordinate.Type+"-"+coordinate.Name+"-"+coordinate.Version) + "-" + hash(coordinate.String())
+}
+
+func newPackage(coordinate v1.Coordinate) Package {
+	return Package{
+		SPDXID:           ID(coordinate),
+		Name:             coordinate.Name,
+		VersionInfo:      coordinate.Version,
+		DownloadLocation: NoAssertion,
+		ExternalRefs: []ExternalRef{{
+			ReferenceCategory: "PACKAGE-MANAGER",
+			ReferenceType:     ReferenceTypePURL,
+			ReferenceLocator:  coordinate.Dependency().PackageURL().String(),
+		}},
+	}
+}
+
+// idPart replaces all characters not allowed in SPDX identifiers by '-'.
+func idPart(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-' {
+			return r
+		}
+		return '-'
+	}, s)
+}
+
+// hash returns the first 8 bytes of the SHA-256 hash of s as hex string.
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// uniqueRelationships returns the sorted relationships without duplicates,
+// whereas DESCRIBES relationships come first.
+func uniqueRelationships(relationships []Relationship) []Relationship {
+	sort.Slice(relationships, func(i, j int) bool {
+		a, b := relationships[i], relationships[j]
+		if (a.RelationshipType == RelationshipDescribes) != (b.RelationshipType == RelationshipDescribes) {
+			return a.RelationshipType == RelationshipDescribes
+		}
+		if a.SPDXElementID != b.SPDXElementID {
+			return a.SPDXElementID < b.SPDXElementID
+		}
+		if a.RelationshipType != b.RelationshipType {
+			return a.RelationshipType < b.RelationshipType
+		}
+		return a.RelatedSPDXElement < b.RelatedSPDXElement
+	})
+
+	var result []Relationship
+	for i, r := range relationships {
+		if i == 0 || r != relationships[i-1] {
+			result = append(result, r)
+		}
+	}
+	return result
+}
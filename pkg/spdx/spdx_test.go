@@ -0,0 +1,127 @@
+package spdx
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+var created = time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// modules returns a module set with an UPSTREAM dependency within the set,
+// an UPSTREAM dependency outside of the set and a DOWNSTREAM dependency.
+func modules() []*v1.Module {
+	return []*v1.Module{
+		{
+			Namespace: "com.example",
+			Name:      "app",
+			Type:      "go",
+			Version:   &v1.ModuleVersion{Name: "v1.0.0"},
+			Dependencies: []*v1.ModuleDependency{
+				{Namespace: "com.example", Name: "lib-core", Type: "go", Version: "v1.2.0"},
+				{Namespace: "org.other", Name: "log.v2", Type: "go", Version: "v2.0.0"},
+				{Namespace: "com.example", Name: "cli", Type: "go", Version: "v1.0.0", Direction: v1.DependencyDirection_DOWNSTREAM.Enum()},
+			},
+		},
+		{
+			Namespace: "com.example",
+			Name:      "lib-core",
+			Type:      "go",
+			Version:   &v1.ModuleVersion{Name: "v1.2.0"},
+		},
+	}
+}
+
+func TestFromModules(t *testing.T) {
+	invalid := modules()
+	invalid[1].Name = "Lib"
+
+	tests := []struct {
+		name              string
+		modules           []*v1.Module
+		opts              Options
+		wantPackages      int
+		wantRelationships int
+		wantNamespace     string
+		wantErr           bool
+	}{
+		{"module set", modules(), Options{Created: created}, 4, 5, "https://opendependency.org/spdx/dependencies-6c8c4c8b388fde44", false},
+		{"configured namespace", modules(), Options{Created: created, Namespace: "https://example.com/spdx/1"}, 4, 5, "https://example.com/spdx/1", false},
+		{"empty module set", nil, Options{Created: created}, 0, 0, "https://opendependency.org/spdx/dependencies-e3b0c44298fc1c14", false},
+		{"duplicate module", append(modules(), modules()[0]), Options{}, 0, 0, "", true},
+		{"invalid module", invalid, Options{}, 0, 0, "", true},
+		{"nil module", []*v1.Module{nil}, Options{}, 0, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromModules(tt.modules, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromModules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got.Packages) != tt.wantPackages || len(got.Relationships) != tt.wantRelationships {
+				t.Errorf("FromModules() = %d packages, %d relationships, want %d, %d",
+					len(got.Packages), len(got.Relationships), tt.wantPackages, tt.wantRelationships)
+			}
+			if got.DocumentNamespace != tt.wantNamespace {
+				t.Errorf("FromModules() namespace = %v, want %v", got.DocumentNamespace, tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	want, err := FromModules(modules(), Options{Created: created})
+	if err != nil {
+		t.Fatalf("FromModules() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, modules(), Options{Created: created}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var got Document
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("WriteJSON() = %+v, want %+v", got, want)
+	}
+}
+
+func TestID(t *testing.T) {
+	tests := []struct {
+		name       string
+		coordinate v1.Coordinate
+		wantPrefix string
+	}{
+		{"simple", v1.Coordinate{Namespace: "com.example", Name: "app", Type: "go", Version: "v1.0.0"}, "SPDXRef-go-app-v1.0.0-"},
+		{"illegal characters", v1.Coordinate{Namespace: "com.example", Name: "app", Type: "go", Version: "v1.0.0+build_1"}, "SPDXRef-go-app-v1.0.0-build-1-"},
+		{"other namespace", v1.Coordinate{Namespace: "org.other", Name: "app", Type: "go", Version: "v1.0.0"}, "SPDXRef-go-app-v1.0.0-"},
+	}
+
+	ids := make(map[string]bool)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ID(tt.coordinate)
+			if !strings.HasPrefix(got, tt.wantPrefix) || len(got) != len(tt.wantPrefix)+16 {
+				t.Errorf("ID() = %v, want prefix %v followed by hash", got, tt.wantPrefix)
+			}
+			if got != ID(tt.coordinate) {
+				t.Errorf("ID() = %v, want deterministic identifier", got)
+			}
+			if ids[got] {
+				t.Errorf("ID() = %v, want unique identifier", got)
+			}
+			ids[got] = true
+		})
+	}
+}
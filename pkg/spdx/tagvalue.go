@@ -0,0 +1,64 @@
+package spdx
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// WriteTagValue converts the modules into an SPDX document and writes it in tag-value format.
+func WriteTagValue(w io.Writer, modules []*v1.Module, opts Options) error {
+	d, err := FromModules(modules, opts)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	writeTag(bw, "SPDXVersion", d.SPDXVersion)
+	writeTag(bw, "DataLicense", d.DataLicense)
+	writeTag(bw, "SPDXID", d.SPDXID)
+	writeTag(bw, "DocumentName", d.Name)
+	writeTag(bw, "DocumentNamespace", d.DocumentNamespace)
+	for _, creator := range d.CreationInfo.Creators {
+		writeTag(bw, "Creator", creator)
+	}
+	writeTag(bw, "Created", d.CreationInfo.Created)
+
+	for _, p := range d.Packages {
+		bw.WriteString("\n##### Package: " + p.Name + "\n\n")
+		writeTag(bw, "PackageName", p.Name)
+		writeTag(bw, "SPDXID", p.SPDXID)
+		if p.VersionInfo != "" {
+			writeTag(bw, "PackageVersion", p.VersionInfo)
+		}
+		writeTag(bw, "PackageDownloadLocation", p.DownloadLocation)
+		if p.FilesAnalyzed {
+			writeTag(bw, "FilesAnalyzed", "true")
+		} else {
+			writeTag(bw, "FilesAnalyzed", "false")
+		}
+		for _, ref := range p.ExternalRefs {
+			writeTag(bw, "ExternalRef", ref.ReferenceCategory+" "+ref.ReferenceType+" "+ref.ReferenceLocator)
+		}
+	}
+
+	if len(d.Relationships) > 0 {
+		bw.WriteString("\n##### Relationships\n\n")
+	}
+	for _, r := range d.Relationships {
+		writeTag(bw, "Relationship", r.SPDXElementID+" "+r.RelationshipType+" "+r.RelatedSPDXElement)
+	}
+
+	return bw.Flush()
+}
+
+// writeTag writes a tag-value pair, whereas multi-line values are enclosed in <text> tags.
+func writeTag(w *bufio.Writer, tag string, value string) {
+	if strings.Contains(value, "\n") {
+		value = "<text>" + value + "</text>"
+	}
+	w.WriteString(tag + ": " + value + "\n")
+}
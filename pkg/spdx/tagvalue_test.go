@@ -0,0 +1,73 @@
+package spdx
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTagValue(t *testing.T) {
+	want := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: <text>multi-line
+name</text>
+DocumentNamespace: https://example.com/spdx/1
+Creator: Tool: go-spec
+Created: 2021-10-01T12:00:00Z
+
+##### Package: app
+
+PackageName: app
+SPDXID: SPDXRef-go-app-v1.0.0-c065f715d7b9223d
+PackageVersion: v1.0.0
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+ExternalRef: PACKAGE-MANAGER purl pkg:go/com.example/app@v1.0.0
+
+##### Package: cli
+
+PackageName: cli
+SPDXID: SPDXRef-go-cli-v1.0.0-227757c968aeb3dd
+PackageVersion: v1.0.0
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+ExternalRef: PACKAGE-MANAGER purl pkg:go/com.example/cli@v1.0.0
+
+##### Package: lib-core
+
+PackageName: lib-core
+SPDXID: SPDXRef-go-lib-core-v1.2.0-b4905284e4a0aa6a
+PackageVersion: v1.2.0
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+ExternalRef: PACKAGE-MANAGER purl pkg:go/com.example/lib-core@v1.2.0
+
+##### Package: log.v2
+
+PackageName: log.v2
+SPDXID: SPDXRef-go-log.v2-v2.0.0-ffc736c21cdfb336
+PackageVersion: v2.0.0
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+ExternalRef: PACKAGE-MANAGER purl pkg:go/org.other/log.v2@v2.0.0
+
+##### Relationships
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-go-app-v1.0.0-c065f715d7b9223d
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-go-lib-core-v1.2.0-b4905284e4a0aa6a
+Relationship: SPDXRef-go-app-v1.0.0-c065f715d7b9223d DEPENDENCY_OF SPDXRef-go-cli-v1.0.0-227757c968aeb3dd
+Relationship: SPDXRef-go-app-v1.0.0-c065f715d7b9223d DEPENDS_ON SPDXRef-go-lib-core-v1.2.0-b4905284e4a0aa6a
+Relationship: SPDXRef-go-app-v1.0.0-c065f715d7b9223d DEPENDS_ON SPDXRef-go-log.v2-v2.0.0-ffc736c21cdfb336
+`
+
+	opts := Options{Name: "multi-line\nname", Namespace: "https://example.com/spdx/1", Created: created}
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		if err := WriteTagValue(&buf, modules(), opts); err != nil {
+			t.Fatalf("WriteTagValue() error = %v", err)
+		}
+		if got := buf.String(); got != want {
+			t.Fatalf("WriteTagValue() =\n%s\nwant\n%s", got, want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Coordinate identifies a module by namespace, name, type and version.
+// Its canonical string form is namespace/name:type@version.
+type Coordinate struct {
+	// Namespace specifies the module namespace.
+	Namespace string
+	// Name specifies the module name.
+	Name string
+	// Type specifies the module type.
+	Type string
+	// Version specifies the module version name.
+	Version string
+}
+
+// ParseCoordinate parses the canonical string form namespace/name:type@version.
+// All parts must fulfil the same constraints as the corresponding module fields.
+func ParseCoordinate(s string) (Coordinate, error) {
+	namespace, rest, ok := cut(s, "/")
+	if !ok {
+		return Coordinate{}, fmt.Errorf("coordinate %q: must have the form namespace/name:type@version", s)
+	}
+	name, rest, ok := cut(rest, ":")
+	if !ok {
+		return Coordinate{}, fmt.Errorf("coordinate %q: must have the form namespace/name:type@version", s)
+	}
+	type_, version, ok := cut(rest, "@")
+	if !ok {
+		return Coordinate{}, fmt.Errorf("coordinate %q: must have the form namespace/name:type@version", s)
+	}
+
+	c := Coordinate{
+		Namespace: namespace,
+		Name:      name,
+		Type:      type_,
+		Version:   version,
+	}
+	if err := c.Validate(); err != nil {
+		return Coordinate{}, fmt.Errorf("coordinate %q: %w", s, err)
+	}
+
+	return c, nil
+}
+
+// Validate checks if the specification constraints are fulfilled.
+// It returns the first violation found.
+func (c Coordinate) Validate() error {
+	return c.violations().first()
+}
+
+func (c Coordinate) violations() ValidationErrors {
+	var errs ValidationErrors
+
+	errs.add("namespace", validateModuleNamespace(c.Namespace))
+	errs.add("name", validateModuleName(c.Name))
+	errs.add("type", validateModuleType(c.Type))
+	errs.add("version", validateModuleVersionName(c.Version))
+
+	return errs
+}
+
+// String returns the canonical string form namespace/name:type@version.
+func (c Coordinate) String() string {
+	return c.Namespace + "/" + c.Name + ":" + c.Type + "@" + c.Version
+}
+
+// Module returns a module identified by the coordinate.
+func (c Coordinate) Module() *Module {
+	return &Module{
+		Namespace: c.Namespace,
+		Name:      c.Name,
+		Type:      c.Type,
+		Version:   &ModuleVersion{Name: c.Version},
+	}
+}
+
+// Dependency returns an upstream dependency to the module identified by the coordinate.
+func (c Coordinate) Dependency() *ModuleDependency {
+	return &ModuleDependency{
+		Namespace: c.Namespace,
+		Name:      c.Name,
+		Type:      c.Type,
+		Version:   c.Version,
+	}
+}
+
+// Coordinate returns the coordinate identifying the module.
+func (x *Module) Coordinate() Coordinate {
+	return Coordinate{
+		Namespace: x.GetNamespace(),
+		Name:      x.GetName(),
+		Type:      x.GetType(),
+		Version:   x.GetVersion().GetName(),
+	}
+}
+
+// Coordinate returns the coordinate identifying the dependent module.
+func (x *ModuleDependency) Coordinate() Coordinate {
+	return Coordinate{
+		Namespace: x.GetNamespace(),
+		Name:      x.GetName(),
+		Type:      x.GetType(),
+		Version:   x.GetVersion(),
+	}
+}
+
+// cut slices s around the first instance of sep.
+func cut(s string, sep string) (before string, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"testing"
+)
+
+func TestParseCoordinate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Coordinate
+		wantErr bool
+	}{
+		{"is valid", "com.example/product:go@v1.0.0", Coordinate{Namespace: "com.example", Name: "product", Type: "go", Version: "v1.0.0"}, false},
+		{"is empty", "", Coordinate{}, true},
+		{"misses namespace separator", "com.example.product:go@v1.0.0", Coordinate{}, true},
+		{"misses type separator", "com.example/product@v1.0.0", Coordinate{}, true},
+		{"misses version separator", "com.example/product:go", Coordinate{}, true},
+		{"has empty version", "com.example/product:go@", Coordinate{}, true},
+		{"has invalid namespace", "Com.Example/product:go@v1.0.0", Coordinate{}, true},
+		{"has invalid name", "com.example/1product:go@v1.0.0", Coordinate{}, true},
+		{"has invalid type", "com.example/product:go-@v1.0.0", Coordinate{}, true},
+		{"has invalid version", "com.example/product:go@v1.0.0+build", Coordinate{}, true},
+		{"has additional separator", "com.example/product/sub:go@v1.0.0", Coordinate{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCoordinate(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCoordinate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCoordinate() = %v, want %v", got, tt.want)
+			}
+			if err == nil && got.String() != tt.s {
+				t.Errorf("String() = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestCoordinate_conversions(t *testing.T) {
+	c := Coordinate{Namespace: "com.example", Name: "product", Type: "go", Version: "v1.0.0"}
+
+	m := c.Module()
+	if err := m.Validate(); err != nil {
+		t.Errorf("Module() is invalid: %v", err)
+	}
+	if got := m.Coordinate(); got != c {
+		t.Errorf("Module().Coordinate() = %v, want %v", got, c)
+	}
+
+	d := c.Dependency()
+	if err := d.Validate(); err != nil {
+		t.Errorf("Dependency() is invalid: %v", err)
+	}
+	if d.GetDirection() != DependencyDirection_UPSTREAM {
+		t.Errorf("Dependency() direction = %v, want UPSTREAM", d.GetDirection())
+	}
+	if got := d.Coordinate(); got != c {
+		t.Errorf("Dependency().Coordinate() = %v, want %v", got, c)
+	}
+
+	var nilModule *Module
+	if got := nilModule.Coordinate(); got != (Coordinate{}) {
+		t.Errorf("nil Module Coordinate() = %v, want zero value", got)
+	}
+}
@@ -0,0 +1,227 @@
+package v1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// DigestAlgorithmSHA256 is the SHA-256 digest algorithm, which is used by default.
+	DigestAlgorithmSHA256 = "sha256"
+	// DigestAlgorithmSHA384 is the SHA-384 digest algorithm.
+	DigestAlgorithmSHA384 = "sha384"
+	// DigestAlgorithmSHA512 is the SHA-512 digest algorithm.
+	DigestAlgorithmSHA512 = "sha512"
+)
+
+// canonicalModuleHeader starts the canonical form of a module, so that the form can evolve.
+const canonicalModuleHeader = "opendependency.org/spec/v1.Module\x00"
+
+var (
+	digestAlgorithmsMu sync.RWMutex
+	digestAlgorithms   = map[string]func() hash.Hash{
+		DigestAlgorithmSHA256: sha256.New,
+		DigestAlgorithmSHA384: sha512.New384,
+		DigestAlgorithmSHA512: sha512.New,
+	}
+)
+
+// RegisterDigestAlgorithm makes a digest algorithm available under the given name.
+// It panics if the name is not made of lowercase alphanumeric characters, dashes and dots,
+// the hash function is nil or an algorithm with the same name is already registered.
+func RegisterDigestAlgorithm(name string, newHash func() hash.Hash) {
+	if !isLowercaseAlphanumericDashDot(name) {
+		panic(fmt.Sprintf("register digest algorithm %q: must consist of lowercase alphanumeric characters, dashes and dots", name))
+	}
+	if newHash == nil {
+		panic(fmt.Sprintf("register digest algorithm %q: hash function is nil", name))
+	}
+
+	digestAlgorithmsMu.Lock()
+	defer digestAlgorithmsMu.Unlock()
+
+	if _, exists := digestAlgorithms[name]; exists {
+		panic(fmt.Sprintf("register digest algorithm %q: already registered", name))
+	}
+	digestAlgorithms[name] = newHash
+}
+
+// unregisterDigestAlgorithm removes the digest algorithm registered under the given name.
+// It allows tests to undo their registrations.
+func unregisterDigestAlgorithm(name string) {
+	digestAlgorithmsMu.Lock()
+	defer digestAlgorithmsMu.Unlock()
+
+	delete(digestAlgorithms, name)
+}
+
+// LookupDigestAlgorithm returns the hash function of the digest algorithm registered under the given name.
+func LookupDigestAlgorithm(name string) (func() hash.Hash, bool) {
+	digestAlgorithmsMu.RLock()
+	defer digestAlgorithmsMu.RUnlock()
+
+	newHash, ok := digestAlgorithms[name]
+	return newHash, ok
+}
+
+// DigestAlgorithms returns the sorted names of all registered digest algorithms.
+func DigestAlgorithms() []string {
+	digestAlgorithmsMu.RLock()
+	defer digestAlgorithmsMu.RUnlock()
+
+	names := make([]string, 0, len(digestAlgorithms))
+	for name := range digestAlgorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Digest is the content digest of a module.
+type Digest struct {
+	// Algorithm is the name of the digest algorithm, e.g. sha256.
+	Algorithm string
+	// Sum is the hash sum.
+	Sum []byte
+}
+
+// ParseDigest parses a digest of the form algorithm:hex, e.g. sha256:2c26b46b....
+// If the algorithm is registered, the sum must have the size of its hash function.
+func ParseDigest(s string) (Digest, error) {
+	algorithm, encoded, ok := cut(s, ":")
+	if !ok || !isLowercaseAlphanumericDashDot(algorithm) {
+		return Digest{}, fmt.Errorf("digest %q: must have the form algorithm:hex", s)
+	}
+
+	sum, err := hex.DecodeString(encoded)
+	if err != nil || len(sum) == 0 || encoded != strings.ToLower(encoded) {
+		return Digest{}, fmt.Errorf("digest %q: sum must be lowercase hexadecimal", s)
+	}
+	if newHash, ok := LookupDigestAlgorithm(algorithm); ok && newHash().Size() != len(sum) {
+		return Digest{}, fmt.Errorf("digest %q: sum must have %d bytes", s, newHash().Size())
+	}
+
+	return Digest{Algorithm: algorithm, Sum: sum}, nil
+}
+
+// String returns the digest in the form algorithm:hex.
+func (d Digest) String() string {
+	return d.Algorithm + ":" + hex.EncodeToString(d.Sum)
+}
+
+// Equal reports whether both digests have the same algorithm and sum.
+func (d Digest) Equal(other Digest) bool {
+	return d.Algorithm == other.Algorithm && bytes.Equal(d.Sum, other.Sum)
+}
+
+// DigestOptions configures the digest calculation.
+type DigestOptions struct {
+	// Algorithm is the name of the registered digest algorithm. It defaults to DigestAlgorithmSHA256.
+	Algorithm string
+	// PreserveDependencyOrder digests the dependencies in their given order instead of a normalized order.
+	PreserveDependencyOrder bool
+}
+
+// Digest returns the SHA-256 digest of the module's canonical form.
+// See DigestWithOptions for the canonical form.
+func (x *Module) Digest() Digest {
+	d, _ := x.DigestWithOptions(DigestOptions{})
+	return d
+}
+
+// DigestWithOptions returns the digest of the module's canonical form
+// with the digest calculation configured by opts.
+//
+// The canonical form is independent of the protobuf encoding, so that equal modules
+// have equal digests across processes, Go versions and protobuf library versions:
+//   - Annotations are ordered by key.
+//   - Dependencies are ordered by their canonical form, unless PreserveDependencyOrder is set.
+//     Replaced versions keep their order.
+//   - Unset optional fields are treated like their default values, i.e. a missing version schema
+//     like an empty one and a missing dependency direction like UPSTREAM.
+//     Likewise, a missing version is treated like an empty version and a nil module like an empty module.
+func (x *Module) DigestWithOptions(opts DigestOptions) (Digest, error) {
+	algorithm := opts.Algorithm
+	if algorithm == "" {
+		algorithm = DigestAlgorithmSHA256
+	}
+	newHash, ok := LookupDigestAlgorithm(algorithm)
+	if !ok {
+		return Digest{}, fmt.Errorf("unknown digest algorithm %q", algorithm)
+	}
+	h := newHash()
+	h.Write(x.canonicalForm(opts.PreserveDependencyOrder))
+	return Digest{Algorithm: algorithm, Sum: h.Sum(nil)}, nil
+}
+
+// canonicalForm encodes the module as a header followed by its fields in field number order.
+// Strings are prefixed by their length in bytes and lists and maps by their number of entries,
+// both as unsigned varint. Dependencies are encoded as strings of their canonical form.
+func (x *Module) canonicalForm(preserveDependencyOrder bool) []byte {
+	var b canonicalBuffer
+	b.WriteString(canonicalModuleHeader)
+
+	b.string(x.GetNamespace())
+	b.string(x.GetName())
+	b.string(x.GetType())
+
+	b.string(x.GetVersion().GetName())
+	b.string(x.GetVersion().GetSchema())
+	b.uvarint(uint64(len(x.GetVersion().GetReplaces())))
+	for _, replaced := range x.GetVersion().GetReplaces() {
+		b.string(replaced)
+	}
+
+	keys := sortedKeys(x.GetAnnotations())
+	b.uvarint(uint64(len(keys)))
+	for _, key := range keys {
+		b.string(key)
+		b.string(x.GetAnnotations()[key])
+	}
+
+	dependencies := make([]string, 0, len(x.GetDependencies()))
+	for _, d := range x.GetDependencies() {
+		dependencies = append(dependencies, d.canonicalForm())
+	}
+	if !preserveDependencyOrder {
+		sort.Strings(dependencies)
+	}
+	b.uvarint(uint64(len(dependencies)))
+	for _, d := range dependencies {
+		b.string(d)
+	}
+
+	return b.Bytes()
+}
+
+func (x *ModuleDependency) canonicalForm() string {
+	var b canonicalBuffer
+	b.string(x.GetNamespace())
+	b.string(x.GetName())
+	b.string(x.GetType())
+	b.string(x.GetVersion())
+	b.uvarint(uint64(uint32(x.GetDirection())))
+	return b.String()
+}
+
+type canonicalBuffer struct {
+	bytes.Buffer
+}
+
+func (b *canonicalBuffer) uvarint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	b.Write(buf[:binary.PutUvarint(buf[:], v)])
+}
+
+func (b *canonicalBuffer) string(s string) {
+	b.uvarint(uint64(len(s)))
+	b.WriteString(s)
+}
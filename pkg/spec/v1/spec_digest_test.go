@@ -0,0 +1,220 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"hash"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func digestModule() *Module {
+	schema := VersionSchemaSemVer
+	return &Module{
+		Namespace: "com.example",
+		Name:      "product",
+		Type:      "go",
+		Version:   &ModuleVersion{Name: "v1.1.0", Schema: &schema, Replaces: []string{"v1.0.0"}},
+		Annotations: map[string]string{
+			"com.example.team":  "platform",
+			"com.example.owner": "jane",
+		},
+		Dependencies: []*ModuleDependency{
+			{Namespace: "com.example", Name: "lib", Type: "go", Version: "v1.0.0"},
+			{Namespace: "com.example", Name: "app", Type: "go", Version: "v2.0.0", Direction: DependencyDirection_DOWNSTREAM.Enum()},
+		},
+	}
+}
+
+func TestModule_Digest(t *testing.T) {
+	// the digest must never change, since it is used as cache key across processes and versions
+	want := "sha256:4daf5315c15a6ebffb90689b79d6014e1e7df3b36282f7dbcc293db9358c7586"
+	if got := digestModule().Digest().String(); got != want {
+		t.Errorf("Digest() = %v, want %v", got, want)
+	}
+}
+
+func TestModule_Digest_equal(t *testing.T) {
+	empty := ""
+	tests := []struct {
+		name   string
+		modify func(m *Module)
+		opts   DigestOptions
+		equal  bool
+	}{
+		{"has same content", func(m *Module) {}, DigestOptions{}, true},
+		{"has annotations in other insertion order", func(m *Module) {
+			m.Annotations = map[string]string{"com.example.owner": "jane"}
+			m.Annotations["com.example.team"] = "platform"
+		}, DigestOptions{}, true},
+		{"has dependencies in other order", func(m *Module) {
+			m.Dependencies[0], m.Dependencies[1] = m.Dependencies[1], m.Dependencies[0]
+		}, DigestOptions{}, true},
+		{"has dependencies in other preserved order", func(m *Module) {
+			m.Dependencies[0], m.Dependencies[1] = m.Dependencies[1], m.Dependencies[0]
+		}, DigestOptions{PreserveDependencyOrder: true}, false},
+		{"has explicit UPSTREAM direction", func(m *Module) {
+			m.Dependencies[0].Direction = DependencyDirection_UPSTREAM.Enum()
+		}, DigestOptions{}, true},
+		{"has other direction", func(m *Module) {
+			m.Dependencies[1].Direction = nil
+		}, DigestOptions{}, false},
+		{"has empty annotations instead of none", func(m *Module) {
+			m.Annotations = nil
+		}, DigestOptions{}, false},
+		{"has other annotation value", func(m *Module) {
+			m.Annotations["com.example.team"] = "security"
+		}, DigestOptions{}, false},
+		{"has moved characters between fields", func(m *Module) {
+			m.Namespace, m.Name = "com.exampl", "eproduct"
+		}, DigestOptions{}, false},
+		{"has other replaces order", func(m *Module) {
+			m.Version.Replaces = []string{"v1.0.0", "v0.9.0"}
+		}, DigestOptions{}, false},
+		{"has other schema", func(m *Module) {
+			m.Version.Schema = &empty
+		}, DigestOptions{}, false},
+		{"has other algorithm", func(m *Module) {}, DigestOptions{Algorithm: DigestAlgorithmSHA512}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := digestModule().DigestWithOptions(DigestOptions{Algorithm: DigestAlgorithmSHA256, PreserveDependencyOrder: tt.opts.PreserveDependencyOrder})
+			if err != nil {
+				t.Fatalf("DigestWithOptions() error = %v", err)
+			}
+
+			m := digestModule()
+			tt.modify(m)
+			got, err := m.DigestWithOptions(tt.opts)
+			if err != nil {
+				t.Fatalf("DigestWithOptions() error = %v", err)
+			}
+			if got.Equal(want) != tt.equal {
+				t.Errorf("DigestWithOptions() = %v, want equal %v to %v", got, tt.equal, want)
+			}
+		})
+	}
+}
+
+func TestModule_Digest_unsetOptionalFields(t *testing.T) {
+	empty := ""
+	tests := []struct {
+		name string
+		a    *Module
+		b    *Module
+	}{
+		{"schema", &Module{Version: &ModuleVersion{Name: "v1"}}, &Module{Version: &ModuleVersion{Name: "v1", Schema: &empty}}},
+		{"direction", &Module{Dependencies: []*ModuleDependency{{Name: "a"}}}, &Module{Dependencies: []*ModuleDependency{{Name: "a", Direction: DependencyDirection_UPSTREAM.Enum()}}}},
+		{"version", &Module{}, &Module{Version: &ModuleVersion{}}},
+		{"module", nil, &Module{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if a, b := tt.a.Digest(), tt.b.Digest(); !a.Equal(b) {
+				t.Errorf("Digest() = %v and %v, want equal", a, b)
+			}
+		})
+	}
+}
+
+func TestModule_DigestWithOptions_algorithms(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		size      int
+		wantErr   bool
+	}{
+		{"", 32, false},
+		{DigestAlgorithmSHA256, 32, false},
+		{DigestAlgorithmSHA384, 48, false},
+		{DigestAlgorithmSHA512, 64, false},
+		{"md5", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			got, err := digestModule().DigestWithOptions(DigestOptions{Algorithm: tt.algorithm})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DigestWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got.Sum) != tt.size {
+				t.Errorf("DigestWithOptions() sum has %d bytes, want %d", len(got.Sum), tt.size)
+			}
+		})
+	}
+}
+
+func TestRegisterDigestAlgorithm(t *testing.T) {
+	RegisterDigestAlgorithm("test-sha256", func() hash.Hash { return sha256.New() })
+	t.Cleanup(func() { unregisterDigestAlgorithm("test-sha256") })
+
+	got, err := digestModule().DigestWithOptions(DigestOptions{Algorithm: "test-sha256"})
+	if err != nil {
+		t.Fatalf("DigestWithOptions() error = %v", err)
+	}
+	if want := digestModule().Digest(); got.Algorithm != "test-sha256" || !reflect.DeepEqual(got.Sum, want.Sum) {
+		t.Errorf("DigestWithOptions() = %v, want sum of %v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		algo    string
+		newHash func() hash.Hash
+	}{
+		{"has invalid name", "SHA:256", sha256.New},
+		{"has no hash function", "test-nil", nil},
+		{"is already registered", DigestAlgorithmSHA256, sha256.New},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterDigestAlgorithm() did not panic")
+				}
+			}()
+			RegisterDigestAlgorithm(tt.algo, tt.newHash)
+		})
+	}
+
+	if algorithms := DigestAlgorithms(); !containsString(algorithms, "test-sha256") || !sort.StringsAreSorted(algorithms) {
+		t.Errorf("DigestAlgorithms() = %v, want sorted names including test-sha256", algorithms)
+	}
+}
+
+func TestParseDigest(t *testing.T) {
+	d := digestModule().Digest()
+	tests := []struct {
+		name    string
+		s       string
+		want    Digest
+		wantErr bool
+	}{
+		{"is valid", d.String(), d, false},
+		{"has unregistered algorithm", "blake3:00ff", Digest{Algorithm: "blake3", Sum: []byte{0x00, 0xff}}, false},
+
+		{"has no algorithm", "00ff", Digest{}, true},
+		{"has invalid algorithm", "SHA256:00ff", Digest{}, true},
+		{"has invalid hex", "sha256:zz", Digest{}, true},
+		{"has uppercase hex", "blake3:00FF", Digest{}, true},
+		{"has no sum", "blake3:", Digest{}, true},
+		{"has wrong size", "sha256:00ff", Digest{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDigest(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDigest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDigest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,349 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// JSONUnmarshalOptions configures the decoding of the proto3 JSON representation.
+type JSONUnmarshalOptions struct {
+	// DiscardUnknown ignores unknown fields and unknown enum value names instead of rejecting them.
+	DiscardUnknown bool
+}
+
+// MarshalJSON encodes the module according to the proto3 JSON mapping,
+// see https://developers.google.com/protocol-buffers/docs/proto3#json.
+// Fields with default values are omitted, except for explicitly set optional fields.
+func (x *Module) MarshalJSON() ([]byte, error) {
+	if x == nil {
+		return []byte("null"), nil
+	}
+
+	var w jsonObjectWriter
+	w.string("namespace", x.Namespace)
+	w.string("name", x.Name)
+	w.string("type", x.Type)
+	if x.Version != nil {
+		w.value("version", x.Version)
+	}
+	if len(x.Annotations) > 0 {
+		w.value("annotations", x.Annotations)
+	}
+	if len(x.Dependencies) > 0 {
+		w.value("dependencies", x.Dependencies)
+	}
+	return w.bytes()
+}
+
+// UnmarshalJSON decodes the module from its proto3 JSON representation. Unknown fields are rejected.
+func (x *Module) UnmarshalJSON(data []byte) error {
+	return x.UnmarshalJSONWithOptions(data, JSONUnmarshalOptions{})
+}
+
+// UnmarshalJSONWithOptions decodes the module from its proto3 JSON representation
+// with the decoding behaviour configured by opts.
+func (x *Module) UnmarshalJSONWithOptions(data []byte, opts JSONUnmarshalOptions) error {
+	fields, err := decodeJSONObject("Module", data, opts, "namespace", "name", "type", "version", "annotations", "dependencies")
+	if err != nil || fields == nil {
+		return err
+	}
+
+	var m struct {
+		namespace, name, type_ string
+		version                *ModuleVersion
+		annotations            map[string]string
+		dependencies           []*ModuleDependency
+	}
+	if err := fields.string("namespace", &m.namespace); err != nil {
+		return err
+	}
+	if err := fields.string("name", &m.name); err != nil {
+		return err
+	}
+	if err := fields.string("type", &m.type_); err != nil {
+		return err
+	}
+	if raw, ok := fields.get("version"); ok {
+		m.version = &ModuleVersion{}
+		if err := m.version.UnmarshalJSONWithOptions(raw, opts); err != nil {
+			return fmt.Errorf("Module.version: %w", err)
+		}
+	}
+	if raw, ok := fields.get("annotations"); ok {
+		if err := json.Unmarshal(raw, &m.annotations); err != nil {
+			return fmt.Errorf("Module.annotations: %w", err)
+		}
+	}
+	if raw, ok := fields.get("dependencies"); ok {
+		var elements []json.RawMessage
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			return fmt.Errorf("Module.dependencies: %w", err)
+		}
+		for i, element := range elements {
+			dependency := &ModuleDependency{}
+			if err := dependency.UnmarshalJSONWithOptions(element, opts); err != nil {
+				return fmt.Errorf("Module.dependencies[%d]: %w", i, err)
+			}
+			m.dependencies = append(m.dependencies, dependency)
+		}
+	}
+
+	x.Namespace = m.namespace
+	x.Name = m.name
+	x.Type = m.type_
+	x.Version = m.version
+	x.Annotations = m.annotations
+	x.Dependencies = m.dependencies
+	return nil
+}
+
+// MarshalJSON encodes the module version according to the proto3 JSON mapping.
+// Fields with default values are omitted, except for an explicitly set schema.
+func (x *ModuleVersion) MarshalJSON() ([]byte, error) {
+	if x == nil {
+		return []byte("null"), nil
+	}
+
+	var w jsonObjectWriter
+	w.string("name", x.Name)
+	if x.Schema != nil {
+		w.value("schema", *x.Schema)
+	}
+	if len(x.Replaces) > 0 {
+		w.value("replaces", x.Replaces)
+	}
+	return w.bytes()
+}
+
+// UnmarshalJSON decodes the module version from its proto3 JSON representation. Unknown fields are rejected.
+func (x *ModuleVersion) UnmarshalJSON(data []byte) error {
+	return x.UnmarshalJSONWithOptions(data, JSONUnmarshalOptions{})
+}
+
+// UnmarshalJSONWithOptions decodes the module version from its proto3 JSON representation
+// with the decoding behaviour configured by opts.
+func (x *ModuleVersion) UnmarshalJSONWithOptions(data []byte, opts JSONUnmarshalOptions) error {
+	fields, err := decodeJSONObject("ModuleVersion", data, opts, "name", "schema", "replaces")
+	if err != nil || fields == nil {
+		return err
+	}
+
+	var name string
+	var schema *string
+	var replaces []string
+	if err := fields.string("name", &name); err != nil {
+		return err
+	}
+	if _, ok := fields.get("schema"); ok {
+		schema = new(string)
+		if err := fields.string("schema", schema); err != nil {
+			return err
+		}
+	}
+	if raw, ok := fields.get("replaces"); ok {
+		if err := json.Unmarshal(raw, &replaces); err != nil {
+			return fmt.Errorf("ModuleVersion.replaces: %w", err)
+		}
+	}
+
+	x.Name = name
+	x.Schema = schema
+	x.Replaces = replaces
+	return nil
+}
+
+// MarshalJSON encodes the module dependency according to the proto3 JSON mapping,
+// whereas the direction is encoded by its name, e.g. "DOWNSTREAM".
+// Fields with default values are omitted, except for an explicitly set direction.
+func (x *ModuleDependency) MarshalJSON() ([]byte, error) {
+	if x == nil {
+		return []byte("null"), nil
+	}
+
+	var w jsonObjectWriter
+	w.string("namespace", x.Namespace)
+	w.string("name", x.Name)
+	w.string("type", x.Type)
+	w.string("version", x.Version)
+	if x.Direction != nil {
+		if name, ok := DependencyDirection_name[int32(*x.Direction)]; ok {
+			w.value("direction", name)
+		} else {
+			w.value("direction", int32(*x.Direction))
+		}
+	}
+	return w.bytes()
+}
+
+// UnmarshalJSON decodes the module dependency from its proto3 JSON representation. Unknown fields are rejected.
+// The direction may be given by its name or its number.
+func (x *ModuleDependency) UnmarshalJSON(data []byte) error {
+	return x.UnmarshalJSONWithOptions(data, JSONUnmarshalOptions{})
+}
+
+// UnmarshalJSONWithOptions decodes the module dependency from its proto3 JSON representation
+// with the decoding behaviour configured by opts.
+func (x *ModuleDependency) UnmarshalJSONWithOptions(data []byte, opts JSONUnmarshalOptions) error {
+	fields, err := decodeJSONObject("ModuleDependency", data, opts, "namespace", "name", "type", "version", "direction")
+	if err != nil || fields == nil {
+		return err
+	}
+
+	var d struct {
+		namespace, name, type_, version string
+		direction                       *DependencyDirection
+	}
+	if err := fields.string("namespace", &d.namespace); err != nil {
+		return err
+	}
+	if err := fields.string("name", &d.name); err != nil {
+		return err
+	}
+	if err := fields.string("type", &d.type_); err != nil {
+		return err
+	}
+	if err := fields.string("version", &d.version); err != nil {
+		return err
+	}
+	if raw, ok := fields.get("direction"); ok {
+		direction, known, err := decodeJSONEnum(raw, DependencyDirection_value)
+		if err != nil {
+			return fmt.Errorf("ModuleDependency.direction: %w", err)
+		}
+		if known {
+			d.direction = DependencyDirection(direction).Enum()
+		} else if !opts.DiscardUnknown {
+			return fmt.Errorf("ModuleDependency.direction: unknown enum value %s", raw)
+		}
+	}
+
+	x.Namespace = d.namespace
+	x.Name = d.name
+	x.Type = d.type_
+	x.Version = d.version
+	x.Direction = d.direction
+	return nil
+}
+
+// jsonObjectWriter writes a JSON object field by field.
+type jsonObjectWriter struct {
+	buf bytes.Buffer
+	err error
+}
+
+// string writes the string field unless it is empty.
+func (w *jsonObjectWriter) string(name string, value string) {
+	if value != "" {
+		w.value(name, value)
+	}
+}
+
+func (w *jsonObjectWriter) value(name string, value interface{}) {
+	if w.err != nil {
+		return
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		w.err = fmt.Errorf("%s: %w", name, err)
+		return
+	}
+
+	if w.buf.Len() == 0 {
+		w.buf.WriteByte('{')
+	} else {
+		w.buf.WriteByte(',')
+	}
+	w.buf.WriteString(`"` + name + `":`)
+	w.buf.Write(encoded)
+}
+
+func (w *jsonObjectWriter) bytes() ([]byte, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	if w.buf.Len() == 0 {
+		return []byte("{}"), nil
+	}
+	w.buf.WriteByte('}')
+	return w.buf.Bytes(), nil
+}
+
+// jsonFields are the fields of a decoded JSON object, whereas fields with null values are absent.
+type jsonFields struct {
+	message string
+	raw     map[string]json.RawMessage
+}
+
+// decodeJSONObject decodes the JSON object of the message and rejects unknown fields unless discarded.
+// It returns nil fields if data is null.
+func decodeJSONObject(message string, data []byte, opts JSONUnmarshalOptions, names ...string) (*jsonFields, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '{' && trimmed[0] != 'n' {
+		return nil, fmt.Errorf("%s: must be a JSON object or null", message)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", message, err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+
+	var unknown []string
+	for name, value := range raw {
+		switch {
+		case !known[name]:
+			unknown = append(unknown, name)
+			delete(raw, name)
+		case bytes.Equal(bytes.TrimSpace(value), []byte("null")):
+			delete(raw, name)
+		}
+	}
+	if len(unknown) > 0 && !opts.DiscardUnknown {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%s: unknown field %q", message, unknown[0])
+	}
+
+	return &jsonFields{message: message, raw: raw}, nil
+}
+
+func (f *jsonFields) get(name string) (json.RawMessage, bool) {
+	raw, ok := f.raw[name]
+	return raw, ok
+}
+
+// string decodes the string field into value if present.
+func (f *jsonFields) string(name string, value *string) error {
+	raw, ok := f.raw[name]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(raw, value); err != nil {
+		return fmt.Errorf("%s.%s: %w", f.message, name, err)
+	}
+	return nil
+}
+
+// decodeJSONEnum decodes an enum value given by its name or number.
+// It reports whether the value is known, whereas numbers are always accepted like proto3 open enums.
+func decodeJSONEnum(raw json.RawMessage, values map[string]int32) (int32, bool, error) {
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		value, ok := values[name]
+		return value, ok, nil
+	}
+
+	var number int32
+	if err := json.Unmarshal(raw, &number); err != nil {
+		return 0, false, fmt.Errorf("must be an enum name or number: %s", raw)
+	}
+	return number, true, nil
+}
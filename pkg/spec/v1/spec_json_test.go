@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestModule_MarshalJSON(t *testing.T) {
+	schema := "semver"
+	empty := ""
+	tests := []struct {
+		name   string
+		module *Module
+		want   string
+	}{
+		{"is empty", &Module{}, `{}`},
+		{"is nil", nil, `null`},
+		{"has all fields", &Module{
+			Namespace:   "com.example",
+			Name:        "product",
+			Type:        "go",
+			Version:     &ModuleVersion{Name: "v1.1.0", Schema: &schema, Replaces: []string{"v1.0.0"}},
+			Annotations: map[string]string{"b": "2", "a": "1"},
+			Dependencies: []*ModuleDependency{
+				{Namespace: "com.example", Name: "lib", Type: "go", Version: "v1.0.0"},
+				{Namespace: "com.example", Name: "app", Type: "go", Version: "v2.0.0", Direction: DependencyDirection_DOWNSTREAM.Enum()},
+			},
+		}, `{"namespace":"com.example","name":"product","type":"go","version":{"name":"v1.1.0","schema":"semver","replaces":["v1.0.0"]},"annotations":{"a":"1","b":"2"},"dependencies":[{"namespace":"com.example","name":"lib","type":"go","version":"v1.0.0"},{"namespace":"com.example","name":"app","type":"go","version":"v2.0.0","direction":"DOWNSTREAM"}]}`},
+		{"has explicit default optional fields", &Module{
+			Version:      &ModuleVersion{Schema: &empty},
+			Dependencies: []*ModuleDependency{{Direction: DependencyDirection_UPSTREAM.Enum()}},
+		}, `{"version":{"schema":""},"dependencies":[{"direction":"UPSTREAM"}]}`},
+		{"has unknown direction", &Module{
+			Dependencies: []*ModuleDependency{{Direction: DependencyDirection(7).Enum()}},
+		}, `{"dependencies":[{"direction":7}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.module)
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_UnmarshalJSON(t *testing.T) {
+	schema := "semver"
+	tests := []struct {
+		name    string
+		data    string
+		opts    JSONUnmarshalOptions
+		want    *Module
+		wantErr string
+	}{
+		{"has all fields", `{"namespace":"com.example","name":"product","type":"go","version":{"name":"v1.1.0","schema":"semver","replaces":["v1.0.0"]},"annotations":{"a":"1"},"dependencies":[{"namespace":"com.example","name":"app","type":"go","version":"v2.0.0","direction":"DOWNSTREAM"}]}`, JSONUnmarshalOptions{}, &Module{
+			Namespace:    "com.example",
+			Name:         "product",
+			Type:         "go",
+			Version:      &ModuleVersion{Name: "v1.1.0", Schema: &schema, Replaces: []string{"v1.0.0"}},
+			Annotations:  map[string]string{"a": "1"},
+			Dependencies: []*ModuleDependency{{Namespace: "com.example", Name: "app", Type: "go", Version: "v2.0.0", Direction: DependencyDirection_DOWNSTREAM.Enum()}},
+		}, ""},
+		{"has direction as number", `{"dependencies":[{"direction":1}]}`, JSONUnmarshalOptions{}, &Module{
+			Dependencies: []*ModuleDependency{{Direction: DependencyDirection_DOWNSTREAM.Enum()}},
+		}, ""},
+		{"has null fields", `{"name":null,"version":null,"dependencies":[{"direction":null}]}`, JSONUnmarshalOptions{}, &Module{
+			Dependencies: []*ModuleDependency{{}},
+		}, ""},
+		{"has discarded unknown fields", `{"name":"product","owner":"me","dependencies":[{"name":"lib","direction":"SIDEWAYS","scope":"test"}]}`, JSONUnmarshalOptions{DiscardUnknown: true}, &Module{
+			Name:         "product",
+			Dependencies: []*ModuleDependency{{Name: "lib"}},
+		}, ""},
+
+		{"has unknown field", `{"name":"product","owner":"me"}`, JSONUnmarshalOptions{}, nil, `Module: unknown field "owner"`},
+		{"has unknown nested field", `{"version":{"name":"v1.0.0","date":"2021-08-01"}}`, JSONUnmarshalOptions{}, nil, `Module.version: ModuleVersion: unknown field "date"`},
+		{"has unknown direction", `{"dependencies":[{"direction":"SIDEWAYS"}]}`, JSONUnmarshalOptions{}, nil, `Module.dependencies[0]: ModuleDependency.direction: unknown enum value "SIDEWAYS"`},
+		{"has invalid direction", `{"dependencies":[{"direction":true}]}`, JSONUnmarshalOptions{}, nil, `Module.dependencies[0]: ModuleDependency.direction: must be an enum name or number: true`},
+		{"has invalid field type", `{"name":1}`, JSONUnmarshalOptions{}, nil, `Module.name: json: cannot unmarshal number into Go value of type string`},
+		{"is no object", `[]`, JSONUnmarshalOptions{}, nil, `Module: must be a JSON object or null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &Module{}
+			err := got.UnmarshalJSONWithOptions([]byte(tt.data), tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("UnmarshalJSONWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalJSONWithOptions() error = %v", err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("UnmarshalJSONWithOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_UnmarshalJSON_isStrict(t *testing.T) {
+	var m Module
+	err := json.Unmarshal([]byte(`{"name":"product","owner":"me"}`), &m)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "owner"`) {
+		t.Errorf("json.Unmarshal() error = %v, want unknown field error", err)
+	}
+}
+
+func TestModule_JSON_roundTrip(t *testing.T) {
+	schema := "semver"
+	empty := ""
+	modules := []*Module{
+		{},
+		{Namespace: "com.example", Name: "product", Type: "go"},
+		{
+			Namespace:   "com.example",
+			Name:        "product",
+			Type:        "go",
+			Version:     &ModuleVersion{Name: "v1.1.0", Schema: &schema, Replaces: []string{"v1.0.0", "v0.9.0"}},
+			Annotations: map[string]string{"com.example.team": "a \"quoted\" <team>", "empty": ""},
+			Dependencies: []*ModuleDependency{
+				{Namespace: "com.example", Name: "lib", Type: "go", Version: "v1.0.0"},
+				{Namespace: "com.example", Name: "lib", Type: "go", Version: "v1.0.0", Direction: DependencyDirection_UPSTREAM.Enum()},
+				{Namespace: "com.example", Name: "app", Type: "go", Version: "v2.0.0", Direction: DependencyDirection_DOWNSTREAM.Enum()},
+			},
+		},
+		{Version: &ModuleVersion{Schema: &empty}},
+		{Dependencies: []*ModuleDependency{{Direction: DependencyDirection(7).Enum()}}},
+	}
+	for _, module := range modules {
+		wire, err := proto.Marshal(module)
+		if err != nil {
+			t.Fatalf("proto.Marshal() error = %v", err)
+		}
+		decoded := &Module{}
+		if err := proto.Unmarshal(wire, decoded); err != nil {
+			t.Fatalf("proto.Unmarshal() error = %v", err)
+		}
+
+		data, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("MarshalJSON() error = %v", err)
+		}
+		got := &Module{}
+		if err := json.Unmarshal(data, got); err != nil {
+			t.Fatalf("UnmarshalJSON(%s) error = %v", data, err)
+		}
+		if !proto.Equal(got, module) {
+			t.Errorf("round trip of %v = %v via %s", module, got, data)
+		}
+
+		gotWire, err := proto.MarshalOptions{Deterministic: true}.Marshal(got)
+		if err != nil {
+			t.Fatalf("proto.Marshal() error = %v", err)
+		}
+		wantWire, _ := proto.MarshalOptions{Deterministic: true}.Marshal(module)
+		if string(gotWire) != string(wantWire) {
+			t.Errorf("binary encoding of %v differs after round trip via %s", module, data)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PackageURL is a package URL of the form pkg:type/namespace/name@version?qualifiers#subpath,
+// see https://github.com/package-url/purl-spec.
+type PackageURL struct {
+	// Type specifies the package type, e.g. maven or npm.
+	Type string
+	// Namespace specifies the optional name prefix. Multiple segments are separated by '/'.
+	Namespace string
+	// Name specifies the package name.
+	Name string
+	// Version specifies the optional package version.
+	Version string
+	// Qualifiers contains optional extra qualifying data.
+	Qualifiers map[string]string
+	// Subpath specifies an optional path inside the package.
+	Subpath string
+}
+
+// PackageURLError reports a package URL which cannot be parsed or represented.
+// If the package URL violates the specification constraints,
+// Err contains the ValidationError describing the violation.
+type PackageURLError struct {
+	// PURL is the affected package URL.
+	PURL string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the package URL followed by the underlying error.
+func (e *PackageURLError) Error() string {
+	return fmt.Sprintf("purl %q: %v", e.PURL, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PackageURLError) Unwrap() error {
+	return e.Err
+}
+
+// ParsePackageURL parses a package URL of the form pkg:type/namespace/name@version?qualifiers#subpath.
+func ParsePackageURL(s string) (PackageURL, error) {
+	p, err := parsePackageURL(s)
+	if err != nil {
+		return PackageURL{}, &PackageURLError{PURL: s, Err: err}
+	}
+	return p, nil
+}
+
+func parsePackageURL(s string) (PackageURL, error) {
+	var p PackageURL
+
+	remainder, subpath, _ := cutLast(s, "#")
+	if subpath != "" {
+		var segments []string
+		for _, segment := range strings.Split(strings.Trim(subpath, "/"), "/") {
+			segment, err := url.PathUnescape(segment)
+			if err != nil {
+				return PackageURL{}, fmt.Errorf("subpath: %w", err)
+			}
+			if segment != "" && segment != "." && segment != ".." {
+				segments = append(segments, segment)
+			}
+		}
+		p.Subpath = strings.Join(segments, "/")
+	}
+
+	remainder, qualifiers, _ := cutLast(remainder, "?")
+	if qualifiers != "" {
+		p.Qualifiers = make(map[string]string)
+		for _, qualifier := range strings.Split(qualifiers, "&") {
+			key, value, ok := cut(qualifier, "=")
+			if !ok || key == "" {
+				return PackageURL{}, fmt.Errorf("qualifier %q: must have the form key=value", qualifier)
+			}
+			value, err := url.PathUnescape(value)
+			if err != nil {
+				return PackageURL{}, fmt.Errorf("qualifier %q: %w", key, err)
+			}
+			if value != "" {
+				p.Qualifiers[strings.ToLower(key)] = value
+			}
+		}
+		if len(p.Qualifiers) == 0 {
+			p.Qualifiers = nil
+		}
+	}
+
+	scheme, remainder, ok := cut(remainder, ":")
+	if !ok || strings.ToLower(scheme) != "pkg" {
+		return PackageURL{}, errors.New("must start with pkg:")
+	}
+	remainder = strings.Trim(remainder, "/")
+
+	type_, remainder, ok := cut(remainder, "/")
+	if !ok || type_ == "" {
+		return PackageURL{}, errors.New("must have the form pkg:type/namespace/name@version")
+	}
+	p.Type = strings.ToLower(type_)
+
+	if i := strings.LastIndex(remainder, "@"); i >= 0 {
+		version, err := url.PathUnescape(remainder[i+1:])
+		if err != nil {
+			return PackageURL{}, fmt.Errorf("version: %w", err)
+		}
+		p.Version = version
+		remainder = remainder[:i]
+	}
+
+	namespace, name, ok := cutLast(strings.TrimRight(remainder, "/"), "/")
+	if !ok {
+		namespace, name = "", namespace
+	}
+
+	name, err := url.PathUnescape(name)
+	if err != nil {
+		return PackageURL{}, fmt.Errorf("name: %w", err)
+	}
+	if name == "" {
+		return PackageURL{}, errors.New("name: must be set")
+	}
+	p.Name = name
+
+	if namespace != "" {
+		var segments []string
+		for _, segment := range strings.Split(namespace, "/") {
+			segment, err := url.PathUnescape(segment)
+			if err != nil {
+				return PackageURL{}, fmt.Errorf("namespace: %w", err)
+			}
+			if segment != "" {
+				segments = append(segments, segment)
+			}
+		}
+		p.Namespace = strings.Join(segments, "/")
+	}
+
+	return p, nil
+}
+
+// String returns the canonical package URL with percent-encoded components and sorted qualifiers.
+func (p PackageURL) String() string {
+	var b strings.Builder
+
+	b.WriteString("pkg:")
+	b.WriteString(p.Type)
+	b.WriteString("/")
+	if p.Namespace != "" {
+		b.WriteString(escapePackageURLPath(p.Namespace))
+		b.WriteString("/")
+	}
+	b.WriteString(escapePackageURLComponent(p.Name))
+
+	if p.Version != "" {
+		b.WriteString("@")
+		b.WriteString(escapePackageURLComponent(p.Version))
+	}
+
+	separator := "?"
+	for _, key := range sortedKeys(p.Qualifiers) {
+		if p.Qualifiers[key] == "" {
+			continue
+		}
+		b.WriteString(separator)
+		b.WriteString(key)
+		b.WriteString("=")
+		b.WriteString(escapePackageURLComponent(p.Qualifiers[key]))
+		separator = "&"
+	}
+
+	if p.Subpath != "" {
+		b.WriteString("#")
+		b.WriteString(escapePackageURLPath(p.Subpath))
+	}
+
+	return b.String()
+}
+
+// Module converts the package URL into a module.
+// The qualifiers become annotations. A *PackageURLError is returned if the package URL
+// has a subpath or violates the specification constraints, e.g. if the namespace has multiple segments.
+func (p PackageURL) Module() (*Module, error) {
+	if p.Subpath != "" {
+		return nil, &PackageURLError{PURL: p.String(), Err: errors.New("subpath cannot be represented as module")}
+	}
+
+	m := &Module{
+		Namespace: p.Namespace,
+		Name:      p.Name,
+		Type:      p.Type,
+	}
+	if p.Version != "" {
+		m.Version = &ModuleVersion{Name: p.Version}
+	}
+	if len(p.Qualifiers) > 0 {
+		m.Annotations = make(map[string]string, len(p.Qualifiers))
+		for k, v := range p.Qualifiers {
+			m.Annotations[k] = v
+		}
+	}
+
+	if err := m.ValidateAll(); err != nil {
+		return nil, &PackageURLError{PURL: p.String(), Err: err}
+	}
+
+	return m, nil
+}
+
+// Dependency converts the package URL into an upstream dependency.
+// A *PackageURLError is returned if the package URL has qualifiers or a subpath
+// or violates the specification constraints, e.g. if the namespace has multiple segments.
+func (p PackageURL) Dependency() (*ModuleDependency, error) {
+	if len(p.Qualifiers) > 0 || p.Subpath != "" {
+		return nil, &PackageURLError{PURL: p.String(), Err: errors.New("qualifiers and subpath cannot be represented as module dependency")}
+	}
+
+	d := &ModuleDependency{
+		Namespace: p.Namespace,
+		Name:      p.Name,
+		Type:      p.Type,
+		Version:   p.Version,
+	}
+
+	if err := d.ValidateAll(); err != nil {
+		return nil, &PackageURLError{PURL: p.String(), Err: err}
+	}
+
+	return d, nil
+}
+
+// PackageURL returns the package URL of the module, whereas the annotations become qualifiers.
+func (x *Module) PackageURL() PackageURL {
+	p := PackageURL{
+		Type:      x.GetType(),
+		Namespace: x.GetNamespace(),
+		Name:      x.GetName(),
+		Version:   x.GetVersion().GetName(),
+	}
+	if len(x.GetAnnotations()) > 0 {
+		p.Qualifiers = make(map[string]string, len(x.GetAnnotations()))
+		for k, v := range x.GetAnnotations() {
+			p.Qualifiers[k] = v
+		}
+	}
+	return p
+}
+
+// PackageURL returns the package URL of the dependent module.
+func (x *ModuleDependency) PackageURL() PackageURL {
+	return PackageURL{
+		Type:      x.GetType(),
+		Namespace: x.GetNamespace(),
+		Name:      x.GetName(),
+		Version:   x.GetVersion(),
+	}
+}
+
+// escapePackageURLPath percent-encodes each '/' separated segment.
+func escapePackageURLPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = escapePackageURLComponent(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapePackageURLComponent percent-encodes all characters except unreserved ones as defined by RFC 3986.
+func escapePackageURLComponent(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+	return b.String()
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s string, sep string) (before string, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
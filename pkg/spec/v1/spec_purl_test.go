@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParsePackageURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		want      PackageURL
+		canonical string
+		wantErr   bool
+	}{
+		{"is simple", "pkg:go/com.example/product@v1.0.0", PackageURL{Type: "go", Namespace: "com.example", Name: "product", Version: "v1.0.0"}, "pkg:go/com.example/product@v1.0.0", false},
+		{"has no namespace", "pkg:npm/lodash@4.17.21", PackageURL{Type: "npm", Name: "lodash", Version: "4.17.21"}, "pkg:npm/lodash@4.17.21", false},
+		{"has no version", "pkg:npm/lodash", PackageURL{Type: "npm", Name: "lodash"}, "pkg:npm/lodash", false},
+		{"has multiple namespace segments", "pkg:golang/github.com/gorilla/context@234fd47e", PackageURL{Type: "golang", Namespace: "github.com/gorilla", Name: "context", Version: "234fd47e"}, "pkg:golang/github.com/gorilla/context@234fd47e", false},
+		{"has encoded characters", "pkg:npm/%40angular/animation@12.3.1", PackageURL{Type: "npm", Namespace: "@angular", Name: "animation", Version: "12.3.1"}, "pkg:npm/%40angular/animation@12.3.1", false},
+		{"has qualifiers", "pkg:maven/org.example/lib@1.0?type=jar&classifier=dist%20all&empty=", PackageURL{Type: "maven", Namespace: "org.example", Name: "lib", Version: "1.0", Qualifiers: map[string]string{"type": "jar", "classifier": "dist all"}}, "pkg:maven/org.example/lib@1.0?classifier=dist%20all&type=jar", false},
+		{"has subpath", "pkg:golang/google.golang.org/genproto#googleapis/api/annotations/", PackageURL{Type: "golang", Namespace: "google.golang.org", Name: "genproto", Subpath: "googleapis/api/annotations"}, "pkg:golang/google.golang.org/genproto#googleapis/api/annotations", false},
+		{"has uppercase type and scheme", "PKG:NPM/lodash", PackageURL{Type: "npm", Name: "lodash"}, "pkg:npm/lodash", false},
+
+		{"has no scheme", "npm/lodash", PackageURL{}, "", true},
+		{"has no name", "pkg:npm/", PackageURL{}, "", true},
+		{"has no type", "pkg:lodash", PackageURL{}, "", true},
+		{"has invalid qualifier", "pkg:npm/lodash?type", PackageURL{}, "", true},
+		{"has invalid encoding", "pkg:npm/lo%zzdash", PackageURL{}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePackageURL(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePackageURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePackageURL() = %+v, want %+v", got, tt.want)
+			}
+			if err == nil && got.String() != tt.canonical {
+				t.Errorf("String() = %v, want %v", got.String(), tt.canonical)
+			}
+		})
+	}
+}
+
+func TestPackageURL_Module(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		wantField string
+		wantErr   bool
+	}{
+		{"is representable", "pkg:go/com.example/product@v1.0.0?com.example.team=a%20team", "", false},
+		{"has multiple namespace segments", "pkg:golang/github.com/gorilla/context@v1.0.0", "namespace", true},
+		{"has no namespace", "pkg:npm/lodash@4.17.21", "namespace", true},
+		{"has uppercase name", "pkg:maven/org.example/Lib@1.0", "name", true},
+		{"has invalid version", "pkg:go/com.example/product@v1.0.0+build", "version.name", true},
+		{"has no version", "pkg:go/com.example/product", "version", true},
+		{"has subpath", "pkg:go/com.example/product@v1.0.0#sub", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePackageURL(tt.s)
+			if err != nil {
+				t.Fatalf("ParsePackageURL() error = %v", err)
+			}
+
+			m, err := p.Module()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Module() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				var purlErr *PackageURLError
+				if !errors.As(err, &purlErr) {
+					t.Errorf("Module() error = %v, want PackageURLError", err)
+				}
+				var validationErr *ValidationError
+				if errors.As(err, &validationErr) != (tt.wantField != "") || (tt.wantField != "" && validationErr.Field != tt.wantField) {
+					t.Errorf("Module() error = %v, want violation of %q", err, tt.wantField)
+				}
+				return
+			}
+
+			if got := m.PackageURL().String(); got != tt.s {
+				t.Errorf("PackageURL() = %v, want %v", got, tt.s)
+			}
+		})
+	}
+}
+
+func TestPackageURL_Dependency(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		wantErr bool
+	}{
+		{"is representable", "pkg:go/com.example/product@v1.0.0", false},
+		{"has qualifiers", "pkg:go/com.example/product@v1.0.0?arch=amd64", true},
+		{"has invalid name", "pkg:go/com.example/Product@v1.0.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePackageURL(tt.s)
+			if err != nil {
+				t.Fatalf("ParsePackageURL() error = %v", err)
+			}
+
+			d, err := p.Dependency()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Dependency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && d.PackageURL().String() != tt.s {
+				t.Errorf("PackageURL() = %v, want %v", d.PackageURL().String(), tt.s)
+			}
+		})
+	}
+}
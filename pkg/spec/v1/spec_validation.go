@@ -4,35 +4,60 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 )
 
 var isLowercaseAlphanumericDashDot = regexp.MustCompile(`^[a-z0-9-.]+$`).MatchString
 
+// ValidationOptions configures optional validation behaviour.
+type ValidationOptions struct {
+	// All returns all violations as ValidationErrors instead of the first one.
+	All bool
+	// AllowVersionConstraints accepts version constraints like ">=1.2.0 <2.0.0" or "^1.4"
+	// as dependency versions, see ParseVersionConstraint.
+	AllowVersionConstraints bool
+}
+
+func (o ValidationOptions) result(errs ValidationErrors) error {
+	if o.All {
+		return errs.err()
+	}
+	return errs.first()
+}
+
 // Validate checks if the specification constraints are fulfilled.
+// It returns the first violation found.
 func (x *Module) Validate() error {
-	if err := validateModuleNamespace(x.Namespace); err != nil {
-		return fmt.Errorf("namespace: %w", err)
-	}
-	if err := validateModuleName(x.Name); err != nil {
-		return fmt.Errorf("name: %w", err)
-	}
-	if err := validateModuleType(x.Type); err != nil {
-		return fmt.Errorf("type: %w", err)
-	}
+	return x.ValidateWithOptions(ValidationOptions{})
+}
 
-	if err := validateModuleVersion(x.Version); err != nil {
-		return fmt.Errorf("version: %w", err)
-	}
+// ValidateAll checks if the specification constraints are fulfilled.
+// In contrast to Validate, it walks the whole module and returns all violations as ValidationErrors.
+func (x *Module) ValidateAll() error {
+	return x.ValidateWithOptions(ValidationOptions{All: true})
+}
 
-	if err := validateModuleAnnotations(x.Annotations); err != nil {
-		return fmt.Errorf("annotations: %w", err)
-	}
+// ValidateWithOptions checks if the specification constraints are fulfilled
+// with the optional validation behaviour configured by opts.
+func (x *Module) ValidateWithOptions(opts ValidationOptions) error {
+	return opts.result(x.violations(opts))
+}
 
-	if err := validateModuleDependencies(x.Dependencies); err != nil {
-		return fmt.Errorf("dependencies: %w", err)
-	}
+func (x *Module) violations(opts ValidationOptions) ValidationErrors {
+	var errs ValidationErrors
 
-	return nil
+	errs.add("namespace", validateModuleNamespace(x.Namespace))
+	errs.add("name", validateModuleName(x.Name))
+	errs.add("type", validateModuleType(x.Type))
+
+	errs.add("version", validateModuleVersion(x.Version))
+
+	errs.add("annotations", validateModuleAnnotations(x.Annotations))
+
+	errs.add("dependencies", validateModuleDependencies(x.Dependencies, opts))
+
+	return errs
 }
 
 func validateModuleNamespace(namespace string) error {
@@ -88,30 +113,37 @@ func validateModuleType(type_ string) error {
 
 func validateModuleVersion(moduleVersion *ModuleVersion) error {
 	if moduleVersion == nil {
-		return errors.New("must be set")
+		return newValidationError(RuleRequired, "", "must be set")
 	}
 
-	return moduleVersion.Validate()
+	return moduleVersion.violations().err()
 }
 
 // Validate checks if the specification constraints are fulfilled.
+// It returns the first violation found.
 func (x *ModuleVersion) Validate() error {
-	if err := validateModuleVersionName(x.Name); err != nil {
-		return fmt.Errorf("name: %w", err)
-	}
-	if x.Schema != nil {
-		if err := validateModuleVersionSchema(*x.Schema); err != nil {
-			return fmt.Errorf("schema: %w", err)
-		}
-	}
+	return x.violations().first()
+}
+
+// ValidateAll checks if the specification constraints are fulfilled.
+// In contrast to Validate, it returns all violations as ValidationErrors.
+func (x *ModuleVersion) ValidateAll() error {
+	return x.violations().err()
+}
+
+func (x *ModuleVersion) violations() ValidationErrors {
+	var errs ValidationErrors
+
+	schema, schemaErr := resolveModuleVersionSchema(x.Schema)
+
+	errs.add("name", validateModuleVersionNameInSchema(x.Name, schema))
+	errs.add("schema", schemaErr)
 
 	for i, v := range x.Replaces {
-		if err := validateModuleVersionName(v); err != nil {
-			return fmt.Errorf("replaces: index %d: %w", i, err)
-		}
+		errs.add(fmt.Sprintf("replaces[%d]", i), validateModuleVersionNameInSchema(v, schema))
 	}
 
-	return nil
+	return errs
 }
 
 func validateModuleVersionName(name string) error {
@@ -131,6 +163,17 @@ func validateModuleVersionName(name string) error {
 	)
 }
 
+func validateModuleVersionConstraint(constraint string) error {
+	return mustFulfilConstraints(
+		func() error {
+			return mustHaveMinMaxLength(constraint, 1, 253)
+		},
+		func() error {
+			return mustBeVersionConstraint(constraint)
+		},
+	)
+}
+
 func validateModuleVersionSchema(schema string) error {
 	return mustFulfilConstraints(
 		func() error {
@@ -148,21 +191,55 @@ func validateModuleVersionSchema(schema string) error {
 	)
 }
 
+// validateModuleVersionNameInSchema checks the general version name constraints
+// and, if given, whether the name follows the version schema.
+func validateModuleVersionNameInSchema(name string, schema VersionSchema) error {
+	if err := validateModuleVersionName(name); err != nil || schema == nil {
+		return err
+	}
+
+	if err := schema.Validate(name); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return err
+		}
+		return newValidationError(RuleFormat, name, "%v", err)
+	}
+
+	return nil
+}
+
+// resolveModuleVersionSchema returns the registered version schema or nil if no schema is set.
+func resolveModuleVersionSchema(schema *string) (VersionSchema, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	if err := validateModuleVersionSchema(*schema); err != nil {
+		return nil, err
+	}
+
+	versionSchema, ok := LookupVersionSchema(*schema)
+	if !ok {
+		return nil, newValidationError(RuleUnknownSchema, *schema, "must be a registered version schema (%s)", strings.Join(VersionSchemas(), ", "))
+	}
+
+	return versionSchema, nil
+}
+
 func validateModuleAnnotations(annotations map[string]string) error {
 	if annotations == nil || len(annotations) == 0 {
 		return nil
 	}
 
-	for k, v := range annotations {
-		if err := validateModuleAnnotationKey(k); err != nil {
-			return fmt.Errorf("key %q: %w", k, err)
-		}
-		if err := validateModuleAnnotationValue(v); err != nil {
-			return fmt.Errorf("value of key %q: %w", k, err)
-		}
+	var errs ValidationErrors
+
+	for _, k := range sortedKeys(annotations) {
+		errs.add(fmt.Sprintf("keys[%q]", k), validateModuleAnnotationKey(k))
+		errs.add(fmt.Sprintf("[%q]", k), validateModuleAnnotationValue(annotations[k]))
 	}
 
-	return nil
+	return errs.err()
 }
 
 func validateModuleAnnotationKey(key string) error {
@@ -190,46 +267,71 @@ func validateModuleAnnotationValue(value string) error {
 	)
 }
 
-func validateModuleDependencies(moduleDependencies []*ModuleDependency) error {
+func validateModuleDependencies(moduleDependencies []*ModuleDependency, opts ValidationOptions) error {
 	if moduleDependencies == nil || len(moduleDependencies) == 0 {
 		return nil
 	}
 
+	var errs ValidationErrors
+
 	for i := 0; i < len(moduleDependencies); i++ {
 		moduleDependency := moduleDependencies[i]
-		if err := moduleDependency.Validate(); err != nil {
-			return fmt.Errorf("index %d: %w", i, err)
-		}
+		errs.add(fmt.Sprintf("[%d]", i), moduleDependency.violations(opts).err())
 	}
 
-	return nil
+	return errs.err()
 }
 
 // Validate checks if the specification constraints are fulfilled.
+// It returns the first violation found.
 func (x *ModuleDependency) Validate() error {
-	if err := validateModuleNamespace(x.Namespace); err != nil {
-		return fmt.Errorf("namespace: %w", err)
-	}
-	if err := validateModuleName(x.Name); err != nil {
-		return fmt.Errorf("name: %w", err)
-	}
-	if err := validateModuleType(x.Type); err != nil {
-		return fmt.Errorf("type: %w", err)
-	}
-	if err := validateModuleVersionName(x.Version); err != nil {
-		return fmt.Errorf("version: %w", err)
+	return x.ValidateWithOptions(ValidationOptions{})
+}
+
+// ValidateAll checks if the specification constraints are fulfilled.
+// In contrast to Validate, it returns all violations as ValidationErrors.
+func (x *ModuleDependency) ValidateAll() error {
+	return x.ValidateWithOptions(ValidationOptions{All: true})
+}
+
+// ValidateWithOptions checks if the specification constraints are fulfilled
+// with the optional validation behaviour configured by opts.
+func (x *ModuleDependency) ValidateWithOptions(opts ValidationOptions) error {
+	return opts.result(x.violations(opts))
+}
+
+func (x *ModuleDependency) violations(opts ValidationOptions) ValidationErrors {
+	var errs ValidationErrors
+
+	errs.add("namespace", validateModuleNamespace(x.Namespace))
+	errs.add("name", validateModuleName(x.Name))
+	errs.add("type", validateModuleType(x.Type))
+	if opts.AllowVersionConstraints {
+		errs.add("version", validateModuleVersionConstraint(x.Version))
+	} else {
+		errs.add("version", validateModuleVersionName(x.Version))
 	}
 
-	return nil
+	return errs
 }
 
 func mustFulfilConstraints(constraints ...func() error) error {
+	var errs ValidationErrors
 	for _, constraint := range constraints {
 		if err := constraint(); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return errs.err()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func mustHaveMinMaxLength(value string, minLen int, maxLen int) error {
@@ -246,10 +348,10 @@ func mustHaveMinMaxLength(value string, minLen int, maxLen int) error {
 	l := len(value)
 
 	if l < minLen {
-		return fmt.Errorf("must have at least %d characters", minLen)
+		return newValidationError(RuleMinLength, value, "must have at least %d characters", minLen)
 	}
 	if l > maxLen {
-		return fmt.Errorf("must have at most %d characters", maxLen)
+		return newValidationError(RuleMaxLength, value, "must have at most %d characters", maxLen)
 	}
 
 	return nil
@@ -261,7 +363,7 @@ func mustBeLowercaseAlphanumericDashDot(value string) error {
 	}
 
 	if !isLowercaseAlphanumericDashDot(value) {
-		return fmt.Errorf("must contain only lowercase alphanumeric characters, '-' or '.'")
+		return newValidationError(RuleCharset, value, "must contain only lowercase alphanumeric characters, '-' or '.'")
 	}
 
 	return nil
@@ -278,7 +380,7 @@ func mustStartWithLowercaseAlphabeticCharacter(value string) error {
 		return nil
 	}
 
-	return fmt.Errorf("must start with lowercase alphabetic character")
+	return newValidationError(RuleStartChar, value, "must start with lowercase alphabetic character")
 }
 
 func mustStartWithLowercaseAlphanumericCharacter(value string) error {
@@ -292,7 +394,7 @@ func mustStartWithLowercaseAlphanumericCharacter(value string) error {
 		return nil
 	}
 
-	return fmt.Errorf("must start with lowercase alphanumeric character")
+	return newValidationError(RuleStartChar, value, "must start with lowercase alphanumeric character")
 }
 
 func mustEndWithLowercaseAlphanumericCharacter(value string) error {
@@ -306,5 +408,29 @@ func mustEndWithLowercaseAlphanumericCharacter(value string) error {
 		return nil
 	}
 
-	return fmt.Errorf("must end with lowercase alphanumeric character")
+	return newValidationError(RuleEndChar, value, "must end with lowercase alphanumeric character")
+}
+
+func mustBeVersionConstraint(value string) error {
+	if len(value) == 0 {
+		return nil
+	}
+
+	alternatives, err := splitVersionConstraint(value)
+	if err != nil {
+		return newValidationError(RuleFormat, value, "%v", err)
+	}
+
+	for _, terms := range alternatives {
+		for _, term := range terms {
+			if term.operator == versionOperatorAny {
+				continue
+			}
+			if err := validateModuleVersionName(term.operand); err != nil {
+				return newValidationError(RuleFormat, value, "version %q: %v", term.operand, err)
+			}
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationRule is a stable code identifying a specification constraint.
+type ValidationRule string
+
+const (
+	// RuleRequired is violated if a mandatory field is not set.
+	RuleRequired ValidationRule = "required"
+	// RuleMinLength is violated if a value is shorter than allowed.
+	RuleMinLength ValidationRule = "min-length"
+	// RuleMaxLength is violated if a value is longer than allowed.
+	RuleMaxLength ValidationRule = "max-length"
+	// RuleCharset is violated if a value contains disallowed characters.
+	RuleCharset ValidationRule = "charset"
+	// RuleStartChar is violated if a value starts with a disallowed character.
+	RuleStartChar ValidationRule = "start-char"
+	// RuleEndChar is violated if a value ends with a disallowed character.
+	RuleEndChar ValidationRule = "end-char"
+	// RuleFormat is violated if a version name does not follow the version schema.
+	RuleFormat ValidationRule = "format"
+	// RuleUnknownSchema is violated if a version schema is not registered.
+	RuleUnknownSchema ValidationRule = "unknown-schema"
+)
+
+// ValidationError describes a single specification constraint violation.
+type ValidationError struct {
+	// Field is the path of the violating field, e.g. dependencies[2].version.
+	// The key of an annotation is located at annotations.keys["key"] and its value at annotations["key"].
+	// It is empty if a single value was validated.
+	Field string
+	// Rule identifies the violated constraint.
+	Rule ValidationRule
+	// Value is the offending value.
+	Value string
+	// Message describes the violation in a readable form.
+	Message string
+}
+
+func newValidationError(rule ValidationRule, value string, format string, a ...interface{}) *ValidationError {
+	return &ValidationError{
+		Rule:    rule,
+		Value:   value,
+		Message: fmt.Sprintf(format, a...),
+	}
+}
+
+// Error returns the field path followed by the message.
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors contains all specification constraint violations found during validation.
+type ValidationErrors []error
+
+// Error returns all violations separated by semicolons.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the contained violations, so that errors.Is and errors.As inspect each of them
+// with Go 1.20 and later.
+func (e ValidationErrors) Unwrap() []error {
+	return e
+}
+
+// Is reports whether any contained violation matches target, so that errors.Is inspects each of them
+// with Go versions before 1.20, which do not support multiple wrapped errors.
+func (e ValidationErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first contained violation that matches target and sets target to it,
+// so that errors.As inspects each of them with Go versions before 1.20.
+func (e ValidationErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// add appends err located at the given field path.
+// Contained violations of nested ValidationErrors are flattened, so that each of them is located on its own.
+func (e *ValidationErrors) add(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	switch err := err.(type) {
+	case ValidationErrors:
+		for _, err := range err {
+			e.add(field, err)
+		}
+	case *ValidationError:
+		located := *err
+		located.Field = joinFieldPath(field, err.Field)
+		*e = append(*e, &located)
+	default:
+		*e = append(*e, fmt.Errorf("%s: %w", field, err))
+	}
+}
+
+// first returns the first violation or nil if there is none.
+func (e ValidationErrors) first() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e[0]
+}
+
+// err returns the violations as error or nil if there are none.
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// joinFieldPath joins a parent field path with a child path like "name" or "[2].version".
+func joinFieldPath(parent string, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	default:
+		return parent + "." + child
+	}
+}
@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestValidationErrors_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		errs ValidationErrors
+		want string
+	}{
+		{"has single violation", ValidationErrors{&ValidationError{Field: "name", Message: "a"}}, "name: a"},
+		{"has multiple violations", ValidationErrors{&ValidationError{Field: "name", Message: "a"}, errors.New("b")}, "name: a; b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.errs.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationErrors_add(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		err   error
+		want  string
+	}{
+		{"is nil", "name", nil, ""},
+		{"is plain error", "name", errors.New("a"), "name: a"},
+		{"is violation of single value", "name", &ValidationError{Message: "a"}, "name: a"},
+		{"is nested violation", "dependencies", &ValidationError{Field: "[2].version", Message: "a"}, "dependencies[2].version: a"},
+		{"is nested field", "version", &ValidationError{Field: "replaces[0]", Message: "a"}, "version.replaces[0]: a"},
+		{"are nested violations", "version", ValidationErrors{&ValidationError{Field: "name", Message: "a"}, &ValidationError{Field: "schema", Message: "b"}}, "version.name: a; version.schema: b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs ValidationErrors
+			errs.add(tt.field, tt.err)
+			if got := errs.Error(); got != tt.want {
+				t.Errorf("add() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationError_errorsAs(t *testing.T) {
+	x := &Module{
+		Namespace: "com.example",
+		Name:      "product",
+		Type:      "go",
+		Version:   &ModuleVersion{Name: "v1.0.0"},
+		Dependencies: []*ModuleDependency{
+			{Namespace: "com.example", Name: "a", Type: "go", Version: "v1.0.0"},
+			{Namespace: "com.example", Name: "b", Type: "go", Version: "v1.0.0"},
+			{Namespace: "com.example", Name: "c", Type: "go", Version: "&%"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"Validate", x.Validate()},
+		{"ValidateAll", x.ValidateAll()},
+		{"wrapped", fmt.Errorf("manifest: %w", x.ValidateAll())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *ValidationError
+			if !errors.As(tt.err, &got) {
+				t.Fatalf("errors.As() = false, err = %v", tt.err)
+			}
+			want := ValidationError{
+				Field:   "dependencies[2].version",
+				Rule:    RuleCharset,
+				Value:   "&%",
+				Message: "must contain only lowercase alphanumeric characters, '-' or '.'",
+			}
+			if *got != want {
+				t.Errorf("errors.As() = %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
+
+func TestValidationErrors_IsAs(t *testing.T) {
+	errSentinel := errors.New("sentinel")
+	violation := &ValidationError{Field: "name", Rule: RuleRequired, Message: "a"}
+	errs := ValidationErrors{errors.New("b"), fmt.Errorf("version: %w", errSentinel), violation}
+
+	// call the methods directly, since errors.Is and errors.As of Go 1.20 and later use Unwrap instead
+	if !errs.Is(errSentinel) {
+		t.Errorf("Is() = false, want true")
+	}
+	if errs.Is(errors.New("sentinel")) {
+		t.Errorf("Is() of other error = true, want false")
+	}
+
+	var got *ValidationError
+	if !errs.As(&got) || got != violation {
+		t.Errorf("As() = %v, want %v", got, violation)
+	}
+	var timeoutErr interface{ Timeout() bool }
+	if errs.As(&timeoutErr) {
+		t.Errorf("As() of other type = true, want false")
+	}
+}
+
+func TestValidationError_rules(t *testing.T) {
+	tests := []struct {
+		name   string
+		module *Module
+		want   []string
+	}{
+		{"is empty", &Module{}, []string{
+			"namespace min-length",
+			"name min-length",
+			"type min-length",
+			"version required",
+		}},
+		{"has invalid values", &Module{
+			Namespace:   "1com",
+			Name:        "product-",
+			Type:        "go",
+			Version:     &ModuleVersion{Name: "v1.0.0", Replaces: []string{"V1"}},
+			Annotations: map[string]string{"key": string(make([]byte, 254))},
+		}, []string{
+			"namespace start-char",
+			"name end-char",
+			"version.replaces[0] charset",
+			"version.replaces[0] start-char",
+			`annotations["key"] max-length`,
+		}},
+		{"has invalid annotation key and value", &Module{
+			Namespace:   "com.example",
+			Name:        "product",
+			Type:        "go",
+			Version:     &ModuleVersion{Name: "v1.0.0"},
+			Annotations: map[string]string{"kEy": string(make([]byte, 254))},
+		}, []string{
+			`annotations.keys["kEy"] charset`,
+			`annotations["kEy"] max-length`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs ValidationErrors
+			errors.As(tt.module.ValidateAll(), &errs)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("ValidateAll() = %v, want %d violations", errs, len(tt.want))
+			}
+			for i, err := range errs {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("ValidateAll()[%d] = %v, want ValidationError", i, err)
+				}
+				if got := validationErr.Field + " " + string(validationErr.Rule); got != tt.want[i] {
+					t.Errorf("ValidateAll()[%d] = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -45,6 +46,57 @@ func TestModule_Validate(t *testing.T) {
 	}
 }
 
+func TestModule_ValidateAll(t *testing.T) {
+	type fields struct {
+		Namespace    string
+		Name         string
+		Type         string
+		Version      *ModuleVersion
+		Annotations  map[string]string
+		Dependencies []*ModuleDependency
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		wantErrLen int
+	}{
+		{"is valid", fields{Namespace: "com.example", Name: "product", Type: "go", Version: &ModuleVersion{Name: "v1.0.0"}, Annotations: nil, Dependencies: nil}, 0},
+		{"is empty", fields{Namespace: "", Name: "", Type: "", Version: nil, Annotations: nil, Dependencies: nil}, 4},
+		{"has invalid namespace", fields{Namespace: "1com.example-", Name: "product", Type: "go", Version: &ModuleVersion{Name: "v1.0.0"}, Annotations: nil, Dependencies: nil}, 2},
+		{"has invalid annotations", fields{Namespace: "com.example", Name: "product", Type: "go", Version: &ModuleVersion{Name: "v1.0.0"}, Annotations: map[string]string{"&%": "", "A": ""}, Dependencies: nil}, 6},
+		{"has invalid version and dependency entries", fields{Namespace: "com.example", Name: "product", Type: "go", Version: &ModuleVersion{Name: "", Replaces: []string{""}}, Annotations: nil, Dependencies: []*ModuleDependency{{}, {}}}, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &Module{
+				Namespace:    tt.fields.Namespace,
+				Name:         tt.fields.Name,
+				Type:         tt.fields.Type,
+				Version:      tt.fields.Version,
+				Annotations:  tt.fields.Annotations,
+				Dependencies: tt.fields.Dependencies,
+			}
+			err := x.ValidateAll()
+			if tt.wantErrLen == 0 {
+				if err != nil {
+					t.Errorf("ValidateAll() error = %v, want nil", err)
+				}
+				return
+			}
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("ValidateAll() error = %v, want ValidationErrors", err)
+			}
+			if len(errs) != tt.wantErrLen {
+				t.Errorf("ValidateAll() error count = %d, want %d: %v", len(errs), tt.wantErrLen, err)
+			}
+			if got, want := x.Validate().Error(), errs[0].Error(); got != want {
+				t.Errorf("Validate() error = %v, want first violation %v", got, want)
+			}
+		})
+	}
+}
+
 func Test_validateModuleNamespace(t *testing.T) {
 	type args struct {
 		namespace string
@@ -160,8 +212,9 @@ func Test_validateModuleVersion(t *testing.T) {
 }
 
 func TestModuleVersion_Validate(t *testing.T) {
-	validSchema := "my-schema"
+	validSchema := "semver"
 	invalidSchema := "%&/"
+	unknownSchema := "my-schema"
 
 	type fields struct {
 		Name     string
@@ -177,6 +230,10 @@ func TestModuleVersion_Validate(t *testing.T) {
 		{"has valid name", fields{Name: "v1.0.0", Schema: nil, Replaces: nil}, false},
 		{"has invalid schema", fields{Name: "v1.0.0", Schema: &invalidSchema, Replaces: nil}, true},
 		{"has valid schema", fields{Name: "v1.0.0", Schema: &validSchema, Replaces: nil}, false},
+		{"has unknown schema", fields{Name: "v1.0.0", Schema: &unknownSchema, Replaces: nil}, true},
+		{"has name not following schema", fields{Name: "2021-08-30", Schema: &validSchema, Replaces: nil}, true},
+		{"has replaces entry not following schema", fields{Name: "v1.1.0", Schema: &validSchema, Replaces: []string{"v1.0"}}, true},
+		{"has replaces entries following schema", fields{Name: "v1.1.0", Schema: &validSchema, Replaces: []string{"v1.0.0", "1.0.0-rc.1"}}, false},
 		{"has invalid replaces entry", fields{Name: "v1.0.0", Schema: nil, Replaces: []string{""}}, true},
 		{"has valid replaces entry", fields{Name: "v1.1.0", Schema: nil, Replaces: []string{"v1.0.0"}}, false},
 	}
@@ -194,6 +251,40 @@ func TestModuleVersion_Validate(t *testing.T) {
 	}
 }
 
+func TestModuleVersion_ValidateAll(t *testing.T) {
+	invalidSchema := "%&/"
+
+	type fields struct {
+		Name     string
+		Schema   *string
+		Replaces []string
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		wantErrLen int
+	}{
+		{"is valid", fields{Name: "v1.1.0", Schema: nil, Replaces: []string{"v1.0.0"}}, 0},
+		{"has invalid name and replaces entries", fields{Name: "", Schema: nil, Replaces: []string{"", "v1.0.0", "-"}}, 4},
+		{"has invalid schema", fields{Name: "v1.0.0", Schema: &invalidSchema, Replaces: nil}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &ModuleVersion{
+				Name:     tt.fields.Name,
+				Schema:   tt.fields.Schema,
+				Replaces: tt.fields.Replaces,
+			}
+			err := x.ValidateAll()
+			var errs ValidationErrors
+			errors.As(err, &errs)
+			if len(errs) != tt.wantErrLen {
+				t.Errorf("ValidateAll() error = %v, want %d violations", err, tt.wantErrLen)
+			}
+		})
+	}
+}
+
 func Test_validateModuleVersionName(t *testing.T) {
 	type args struct {
 		name string
@@ -374,7 +465,7 @@ func Test_validateModuleDependencies(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := validateModuleDependencies(tt.args.moduleDependencies); (err != nil) != tt.wantErr {
+			if err := validateModuleDependencies(tt.args.moduleDependencies, ValidationOptions{}); (err != nil) != tt.wantErr {
 				t.Errorf("validateModuleDependencies() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -419,6 +510,28 @@ func TestModuleDependency_Validate(t *testing.T) {
 	}
 }
 
+func TestModuleDependency_ValidateAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		dependency *ModuleDependency
+		wantErrLen int
+	}{
+		{"is valid", &ModuleDependency{Namespace: "com.example", Name: "product", Type: "go", Version: "v1.0.0"}, 0},
+		{"is empty", &ModuleDependency{}, 4},
+		{"has invalid name and version", &ModuleDependency{Namespace: "com.example", Name: "PRODUCT", Type: "go", Version: "&%"}, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dependency.ValidateAll()
+			var errs ValidationErrors
+			errors.As(err, &errs)
+			if len(errs) != tt.wantErrLen {
+				t.Errorf("ValidateAll() error = %v, want %d violations", err, tt.wantErrLen)
+			}
+		})
+	}
+}
+
 func Test_mustHaveMinMaxLength(t *testing.T) {
 	type args struct {
 		value  string
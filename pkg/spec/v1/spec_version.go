@@ -0,0 +1,526 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionKey is the schema specific, orderable representation of a version name.
+type VersionKey interface {
+	// Compare returns a negative number, zero or a positive number if the key is less than,
+	// equal to or greater than other. other is always parsed by the same version schema.
+	Compare(other VersionKey) int
+}
+
+// Version is a module version name parsed according to its version schema.
+type Version struct {
+	schema string
+	name   string
+	key    VersionKey
+}
+
+// ParseVersion parses the version name according to the named version schema.
+func ParseVersion(name string, schema string) (Version, error) {
+	versionSchema, ok := LookupVersionSchema(schema)
+	if !ok {
+		return Version{}, fmt.Errorf("unknown version schema %q", schema)
+	}
+
+	parser, ok := versionSchema.(VersionParser)
+	if !ok {
+		return Version{}, fmt.Errorf("version schema %q does not support parsing", schema)
+	}
+
+	key, err := parser.ParseKey(name)
+	if err != nil {
+		return Version{}, fmt.Errorf("version %q: %w", name, err)
+	}
+
+	return Version{schema: schema, name: name, key: key}, nil
+}
+
+// Parse parses the version name according to the version schema.
+// If no schema is set, the free-form schema is used.
+func (x *ModuleVersion) Parse() (Version, error) {
+	schema := VersionSchemaFreeForm
+	if s := x.GetSchema(); s != "" {
+		schema = s
+	}
+
+	return ParseVersion(x.GetName(), schema)
+}
+
+// Schema returns the name of the version schema the version was parsed with.
+func (v Version) Schema() string {
+	return v.schema
+}
+
+// String returns the original version name.
+func (v Version) String() string {
+	return v.name
+}
+
+// Compare returns -1, 0 or +1 if v is less than, equal to or greater than other.
+// Versions of different schemas are ordered by their schema name.
+func (v Version) Compare(other Version) int {
+	if v.schema != other.schema {
+		return strings.Compare(v.schema, other.schema)
+	}
+
+	switch {
+	case v.key == nil && other.key == nil:
+		return 0
+	case v.key == nil:
+		return -1
+	case other.key == nil:
+		return 1
+	}
+
+	return sign(v.key.Compare(other.key))
+}
+
+// Less reports whether v is less than other.
+func (v Version) Less(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+// Equal reports whether v and other have the same precedence.
+func (v Version) Equal(other Version) bool {
+	return v.Compare(other) == 0
+}
+
+// SortModulesByVersion sorts the modules in ascending version order.
+// If a module version cannot be parsed, the order is not modified and an error is returned.
+func SortModulesByVersion(modules []*Module) error {
+	versions := make(map[*Module]Version, len(modules))
+	for i, module := range modules {
+		if module.GetVersion() == nil {
+			return fmt.Errorf("index %d: version must be set", i)
+		}
+
+		version, err := module.GetVersion().Parse()
+		if err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+		versions[module] = version
+	}
+
+	sort.SliceStable(modules, func(i, j int) bool {
+		return versions[modules[i]].Less(versions[modules[j]])
+	})
+
+	return nil
+}
+
+// versionReleaser is implemented by version keys consisting of numeric release components,
+// e.g. major, minor and patch version. It enables the caret and tilde version constraint operators.
+type versionReleaser interface {
+	release() []uint64
+}
+
+// semVerKey is a semantic version, see https://semver.org.
+type semVerKey struct {
+	major      uint64
+	minor      uint64
+	patch      uint64
+	prerelease []string
+	build      string
+}
+
+func parseSemVerVersion(name string) (VersionKey, error) {
+	errInvalid := errors.New("must be a semantic version like 1.2.3 or v1.2.3-rc.1")
+
+	value := strings.TrimPrefix(name, "v")
+
+	var key semVerKey
+	if i := strings.IndexByte(value, '+'); i >= 0 {
+		key.build = value[i+1:]
+		value = value[:i]
+		if !isSemVerIdentifiers(key.build, false) {
+			return nil, errInvalid
+		}
+	}
+	if i := strings.IndexByte(value, '-'); i >= 0 {
+		prerelease := value[i+1:]
+		value = value[:i]
+		if !isSemVerIdentifiers(prerelease, true) {
+			return nil, errInvalid
+		}
+		key.prerelease = strings.Split(prerelease, ".")
+	}
+
+	core := strings.Split(value, ".")
+	if len(core) != 3 {
+		return nil, errInvalid
+	}
+
+	numbers := []*uint64{&key.major, &key.minor, &key.patch}
+	for i, part := range core {
+		n, ok := parseNumericIdentifier(part)
+		if !ok {
+			return nil, errInvalid
+		}
+		*numbers[i] = n
+	}
+
+	return key, nil
+}
+
+// Compare orders by major, minor and patch version followed by the prerelease identifiers.
+// Build metadata is ignored as defined by the specification.
+func (k semVerKey) Compare(other VersionKey) int {
+	o := other.(semVerKey)
+
+	if c := compareUint64(k.major, o.major); c != 0 {
+		return c
+	}
+	if c := compareUint64(k.minor, o.minor); c != 0 {
+		return c
+	}
+	if c := compareUint64(k.patch, o.patch); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(k.prerelease, o.prerelease)
+}
+
+func (k semVerKey) release() []uint64 {
+	return []uint64{k.major, k.minor, k.patch}
+}
+
+// calVerKey is a calendar version of the form YYYY.MM[.MICRO][-MODIFIER], see https://calver.org.
+type calVerKey struct {
+	year     uint64
+	month    uint64
+	micro    uint64
+	hasMicro bool
+	modifier []string
+}
+
+func parseCalVerVersion(name string) (VersionKey, error) {
+	errInvalid := errors.New("must be a calendar version like 2021.08 or 2021.08.1")
+
+	value := name
+
+	var key calVerKey
+	if i := strings.IndexByte(value, '-'); i >= 0 {
+		modifier := value[i+1:]
+		value = value[:i]
+		if !isSemVerIdentifiers(modifier, false) {
+			return nil, errInvalid
+		}
+		key.modifier = strings.Split(modifier, ".")
+	}
+
+	parts := strings.Split(value, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, errInvalid
+	}
+	for _, part := range parts {
+		if !isDigits(part) {
+			return nil, errInvalid
+		}
+	}
+
+	switch len(parts[0]) {
+	case 4:
+		key.year, _ = strconv.ParseUint(parts[0], 10, 64)
+	case 2:
+		key.year, _ = strconv.ParseUint(parts[0], 10, 64)
+		key.year += 2000
+	default:
+		return nil, errInvalid
+	}
+
+	month, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil || len(parts[1]) > 2 || month < 1 || month > 12 {
+		return nil, errInvalid
+	}
+	key.month = month
+
+	if len(parts) == 3 {
+		micro, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil {
+			return nil, errInvalid
+		}
+		key.micro = micro
+		key.hasMicro = true
+	}
+
+	return key, nil
+}
+
+// Compare orders by year, month and micro version followed by the modifier.
+// A missing micro version is less than any micro version and
+// a modifier marks a pre-release like a semantic version prerelease.
+func (k calVerKey) Compare(other VersionKey) int {
+	o := other.(calVerKey)
+
+	if c := compareUint64(k.year, o.year); c != 0 {
+		return c
+	}
+	if c := compareUint64(k.month, o.month); c != 0 {
+		return c
+	}
+	if k.hasMicro != o.hasMicro {
+		if k.hasMicro {
+			return 1
+		}
+		return -1
+	}
+	if c := compareUint64(k.micro, o.micro); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(k.modifier, o.modifier)
+}
+
+func (k calVerKey) release() []uint64 {
+	if k.hasMicro {
+		return []uint64{k.year, k.month, k.micro}
+	}
+	return []uint64{k.year, k.month}
+}
+
+// dateKey is a date in one of the dateVersionLayouts.
+type dateKey struct {
+	date time.Time
+}
+
+var dateVersionLayouts = []string{"20060102", "2006-01-02", "2006.01.02"}
+
+func parseDateVersion(name string) (VersionKey, error) {
+	for _, layout := range dateVersionLayouts {
+		if date, err := time.Parse(layout, name); err == nil {
+			return dateKey{date: date}, nil
+		}
+	}
+	return nil, errors.New("must be a date like 20210830, 2021-08-30 or 2021.08.30")
+}
+
+// Compare orders chronologically, independent of the date layout.
+func (k dateKey) Compare(other VersionKey) int {
+	o := other.(dateKey)
+
+	switch {
+	case k.date.Before(o.date):
+		return -1
+	case k.date.After(o.date):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (k dateKey) release() []uint64 {
+	return []uint64{uint64(k.date.Year()), uint64(k.date.Month()), uint64(k.date.Day())}
+}
+
+// freeFormKey is an arbitrary version name.
+type freeFormKey struct {
+	name string
+}
+
+func parseFreeFormVersion(name string) (VersionKey, error) {
+	return freeFormKey{name: name}, nil
+}
+
+// Compare orders naturally, so that digit sequences are compared numerically and a leading 'v' is ignored.
+// Names with the same natural order are ordered lexicographically.
+func (k freeFormKey) Compare(other VersionKey) int {
+	o := other.(freeFormKey)
+
+	if c := compareNatural(trimVersionPrefix(k.name), trimVersionPrefix(o.name)); c != 0 {
+		return c
+	}
+
+	return strings.Compare(k.name, o.name)
+}
+
+// release returns all digit sequences, whereas numbers exceeding uint64 are saturated.
+func (k freeFormKey) release() []uint64 {
+	var components []uint64
+	for value := trimVersionPrefix(k.name); value != ""; {
+		var token string
+		token, value = nextNaturalToken(value)
+		if !isDigits(token) {
+			continue
+		}
+		n, err := strconv.ParseUint(token, 10, 64)
+		if err != nil {
+			n = math.MaxUint64
+		}
+		components = append(components, n)
+	}
+	return components
+}
+
+// isSemVerIdentifiers checks for non-empty, dot separated identifiers consisting of [0-9A-Za-z-].
+// Numeric prerelease identifiers must not have leading zeros.
+func isSemVerIdentifiers(value string, prerelease bool) bool {
+	for _, identifier := range strings.Split(value, ".") {
+		if identifier == "" {
+			return false
+		}
+		for _, c := range identifier {
+			if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && c != '-' {
+				return false
+			}
+		}
+		if prerelease && isDigits(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNumericIdentifier parses a number without leading zeros.
+func parseNumericIdentifier(value string) (uint64, bool) {
+	if !isDigits(value) || (len(value) > 1 && value[0] == '0') {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// comparePrerelease compares prerelease identifiers, whereas no identifiers have the highest precedence.
+func comparePrerelease(a []string, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNumeric, bNumeric := isDigits(a[i]), isDigits(b[i])
+
+		var c int
+		switch {
+		case aNumeric && bNumeric:
+			c = compareNumeric(a[i], b[i])
+		case aNumeric:
+			c = -1
+		case bNumeric:
+			c = 1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+
+	return compareUint64(uint64(len(a)), uint64(len(b)))
+}
+
+// compareNatural compares digit sequences numerically and all other sequences lexicographically.
+// Digit sequences are less than other sequences.
+func compareNatural(a string, b string) int {
+	for a != "" && b != "" {
+		var aToken, bToken string
+		aToken, a = nextNaturalToken(a)
+		bToken, b = nextNaturalToken(b)
+
+		aNumeric, bNumeric := isDigits(aToken), isDigits(bToken)
+
+		var c int
+		switch {
+		case aNumeric && bNumeric:
+			c = compareNumeric(aToken, bToken)
+		case aNumeric:
+			c = -1
+		case bNumeric:
+			c = 1
+		default:
+			c = strings.Compare(aToken, bToken)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+
+	return compareUint64(uint64(len(a)), uint64(len(b)))
+}
+
+// nextNaturalToken splits off the leading digit or non-digit sequence.
+func nextNaturalToken(value string) (string, string) {
+	digits := isDigit(value[0])
+
+	i := 1
+	for i < len(value) && isDigit(value[i]) == digits {
+		i++
+	}
+
+	return value[:i], value[i:]
+}
+
+// compareNumeric compares digit sequences of arbitrary length numerically.
+func compareNumeric(a string, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+
+	if c := compareUint64(uint64(len(a)), uint64(len(b))); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a, b)
+}
+
+func compareUint64(a uint64, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// trimVersionPrefix removes a leading 'v' followed by a digit.
+func trimVersionPrefix(value string) string {
+	if len(value) > 1 && value[0] == 'v' && isDigit(value[1]) {
+		return value[1:]
+	}
+	return value
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if !isDigit(value[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,303 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	versionOperatorAny          = "*"
+	versionOperatorEqual        = "="
+	versionOperatorNotEqual     = "!="
+	versionOperatorGreater      = ">"
+	versionOperatorGreaterEqual = ">="
+	versionOperatorLess         = "<"
+	versionOperatorLessEqual    = "<="
+	versionOperatorCaret        = "^"
+	versionOperatorTilde        = "~"
+)
+
+// versionOperators is ordered, so that longer operators are matched first.
+var versionOperators = []string{
+	versionOperatorNotEqual,
+	versionOperatorGreaterEqual,
+	versionOperatorLessEqual,
+	versionOperatorEqual,
+	versionOperatorGreater,
+	versionOperatorLess,
+	versionOperatorCaret,
+	versionOperatorTilde,
+}
+
+var isPartialVersion = regexp.MustCompile(`^v?\d+(\.\d+)?$`).MatchString
+
+// VersionConstraint is a parsed version constraint like ">=1.2.0 <2.0.0 || ^3.1".
+//
+// A constraint consists of one or more alternatives separated by "||". An alternative
+// is satisfied if all of its comparators separated by spaces or commas are satisfied.
+// A comparator is an operator followed by a version or one of the wildcards "*", "x" or "X":
+//
+//	=1.2.0, 1.2.0  equal to 1.2.0
+//	!=1.2.0        not equal to 1.2.0
+//	>1.2.0         greater than 1.2.0, likewise >=, < and <=
+//	^1.4           at least 1.4.0 without changing the left-most non-zero release component, i.e. <2.0.0
+//	~1.4.2         at least 1.4.2 without changing the first two release components, i.e. <1.5.0
+//
+// Versions with fewer release components than required by the version schema,
+// e.g. 1.4 for semver, are completed with zeros.
+type VersionConstraint struct {
+	schema       string
+	text         string
+	alternatives [][]versionComparator
+}
+
+type versionComparator struct {
+	operator string
+	version  Version
+	// given is the number of release components written in the constraint.
+	given int
+}
+
+// versionTerm is the syntactical representation of a versionComparator.
+type versionTerm struct {
+	operator string
+	operand  string
+}
+
+// ParseVersionConstraint parses the version constraint according to the named version schema.
+func ParseVersionConstraint(constraint string, schema string) (VersionConstraint, error) {
+	alternatives, err := splitVersionConstraint(constraint)
+	if err != nil {
+		return VersionConstraint{}, fmt.Errorf("version constraint %q: %w", constraint, err)
+	}
+
+	c := VersionConstraint{schema: schema, text: constraint}
+	for _, terms := range alternatives {
+		var comparators []versionComparator
+		for _, term := range terms {
+			comparator, err := parseVersionComparator(term, schema)
+			if err != nil {
+				return VersionConstraint{}, fmt.Errorf("version constraint %q: %w", constraint, err)
+			}
+			comparators = append(comparators, comparator)
+		}
+		c.alternatives = append(c.alternatives, comparators)
+	}
+
+	return c, nil
+}
+
+// VersionConstraint parses the dependency version as version constraint according to the named version schema.
+// An exact version name is a valid constraint as well.
+func (x *ModuleDependency) VersionConstraint(schema string) (VersionConstraint, error) {
+	return ParseVersionConstraint(x.GetVersion(), schema)
+}
+
+// Schema returns the name of the version schema the constraint was parsed with.
+func (c VersionConstraint) Schema() string {
+	return c.schema
+}
+
+// String returns the original constraint.
+func (c VersionConstraint) String() string {
+	return c.text
+}
+
+// Satisfies reports whether the version satisfies the constraint.
+// Versions of another schema never satisfy the constraint.
+func (c VersionConstraint) Satisfies(version Version) bool {
+	if version.schema != c.schema {
+		return false
+	}
+
+	for _, comparators := range c.alternatives {
+		if satisfiesAll(comparators, version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BestMatch returns the greatest candidate satisfying the constraint.
+func (c VersionConstraint) BestMatch(candidates []Version) (Version, bool) {
+	var best Version
+	found := false
+	for _, candidate := range candidates {
+		if !c.Satisfies(candidate) {
+			continue
+		}
+		if !found || best.Less(candidate) {
+			best = candidate
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+func satisfiesAll(comparators []versionComparator, version Version) bool {
+	for _, comparator := range comparators {
+		if !comparator.satisfies(version) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c versionComparator) satisfies(version Version) bool {
+	switch c.operator {
+	case versionOperatorAny:
+		return true
+	case versionOperatorEqual:
+		return version.Equal(c.version)
+	case versionOperatorNotEqual:
+		return !version.Equal(c.version)
+	case versionOperatorGreater:
+		return c.version.Less(version)
+	case versionOperatorGreaterEqual:
+		return !version.Less(c.version)
+	case versionOperatorLess:
+		return version.Less(c.version)
+	case versionOperatorLessEqual:
+		return !c.version.Less(version)
+	case versionOperatorCaret, versionOperatorTilde:
+		return !version.Less(c.version) && c.sharesRelease(version)
+	default:
+		return false
+	}
+}
+
+// sharesRelease reports whether the version has the same leading release components
+// as the comparator version, whereas the number of components depends on the operator.
+func (c versionComparator) sharesRelease(version Version) bool {
+	want := c.version.key.(versionReleaser).release()
+	releaser, ok := version.key.(versionReleaser)
+	if !ok {
+		return false
+	}
+	got := releaser.release()
+
+	n := c.given
+	if c.operator == versionOperatorTilde && n > 2 {
+		n = 2
+	}
+	if c.operator == versionOperatorCaret {
+		for i := 0; i < c.given; i++ {
+			if want[i] != 0 {
+				n = i + 1
+				break
+			}
+		}
+	}
+
+	if len(got) < n {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func parseVersionComparator(term versionTerm, schema string) (versionComparator, error) {
+	if term.operator == versionOperatorAny {
+		return versionComparator{operator: versionOperatorAny}, nil
+	}
+
+	version, padded, err := parseConstraintVersion(term.operand, schema)
+	if err != nil {
+		return versionComparator{}, err
+	}
+
+	comparator := versionComparator{operator: term.operator, version: version}
+	if term.operator == versionOperatorCaret || term.operator == versionOperatorTilde {
+		releaser, ok := version.key.(versionReleaser)
+		if !ok {
+			return versionComparator{}, fmt.Errorf("operator %q is not supported by version schema %q", term.operator, schema)
+		}
+		comparator.given = len(releaser.release()) - padded
+		if comparator.given < 1 {
+			return versionComparator{}, fmt.Errorf("operator %q requires a version with release components", term.operator)
+		}
+	}
+
+	return comparator, nil
+}
+
+// parseConstraintVersion parses the version and completes missing release components with zeros if required.
+// It returns the number of added components.
+func parseConstraintVersion(operand string, schema string) (Version, int, error) {
+	version, err := ParseVersion(operand, schema)
+	if err == nil || !isPartialVersion(operand) {
+		return version, 0, err
+	}
+
+	padded := operand
+	for i := 1; i <= 2; i++ {
+		padded += ".0"
+		if paddedVersion, paddedErr := ParseVersion(padded, schema); paddedErr == nil {
+			paddedVersion.name = operand
+			return paddedVersion, i, nil
+		}
+	}
+
+	return Version{}, 0, err
+}
+
+// splitVersionConstraint splits the constraint syntactically into alternatives of terms.
+func splitVersionConstraint(constraint string) ([][]versionTerm, error) {
+	if strings.TrimSpace(constraint) == "" {
+		return nil, errors.New("must not be empty")
+	}
+
+	var alternatives [][]versionTerm
+	for _, alternative := range strings.Split(constraint, "||") {
+		fields := strings.Fields(strings.ReplaceAll(alternative, ",", " "))
+		if len(fields) == 0 {
+			return nil, errors.New("must not contain empty alternatives")
+		}
+
+		var terms []versionTerm
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			if field == "*" || field == "x" || field == "X" {
+				terms = append(terms, versionTerm{operator: versionOperatorAny})
+				continue
+			}
+
+			operator := versionOperatorEqual
+			for _, op := range versionOperators {
+				if strings.HasPrefix(field, op) {
+					operator = op
+					field = field[len(op):]
+					break
+				}
+			}
+
+			// an operator may be separated from its version by spaces, e.g. ">= 1.2.0"
+			if field == "" {
+				if i+1 == len(fields) {
+					return nil, fmt.Errorf("operator %q must be followed by a version", operator)
+				}
+				i++
+				field = fields[i]
+			}
+
+			for _, op := range versionOperators {
+				if strings.HasPrefix(field, op) {
+					return nil, fmt.Errorf("version %q must not start with an operator", field)
+				}
+			}
+
+			terms = append(terms, versionTerm{operator: operator, operand: field})
+		}
+		alternatives = append(alternatives, terms)
+	}
+
+	return alternatives, nil
+}
@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"testing"
+)
+
+func TestVersionConstraint_Satisfies(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     string
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"exact: equal", VersionSchemaSemVer, "1.2.0", "v1.2.0", true},
+		{"exact: not equal", VersionSchemaSemVer, "=1.2.0", "1.2.1", false},
+		{"not equal", VersionSchemaSemVer, "!=1.2.0", "1.2.1", true},
+		{"wildcard", VersionSchemaSemVer, "*", "0.0.1", true},
+
+		{"range: lower bound", VersionSchemaSemVer, ">=1.2.0 <2.0.0", "1.2.0", true},
+		{"range: in-between", VersionSchemaSemVer, ">=1.2.0 <2.0.0", "1.9.9", true},
+		{"range: upper bound", VersionSchemaSemVer, ">=1.2.0 <2.0.0", "2.0.0", false},
+		{"range: below", VersionSchemaSemVer, ">=1.2.0 <2.0.0", "1.1.9", false},
+		{"range: comma separated", VersionSchemaSemVer, ">1.2.0, <=2.0.0", "2.0.0", true},
+		{"range: operator separated by space", VersionSchemaSemVer, ">= 1.2.0 < 2.0.0", "1.5.0", true},
+		{"range: partial versions", VersionSchemaSemVer, ">=1.2 <2", "1.2.0", true},
+
+		{"caret: lower bound", VersionSchemaSemVer, "^1.4", "1.4.0", true},
+		{"caret: same major", VersionSchemaSemVer, "^1.4", "1.9.3", true},
+		{"caret: next major", VersionSchemaSemVer, "^1.4", "2.0.0", false},
+		{"caret: below", VersionSchemaSemVer, "^1.4", "1.3.9", false},
+		{"caret: zero major", VersionSchemaSemVer, "^0.2.3", "0.2.9", true},
+		{"caret: zero major next minor", VersionSchemaSemVer, "^0.2.3", "0.3.0", false},
+		{"caret: zero major and minor", VersionSchemaSemVer, "^0.0.3", "0.0.4", false},
+
+		{"tilde: same minor", VersionSchemaSemVer, "~1.4.2", "1.4.9", true},
+		{"tilde: next minor", VersionSchemaSemVer, "~1.4.2", "1.5.0", false},
+		{"tilde: major only", VersionSchemaSemVer, "~1", "1.9.0", true},
+		{"tilde: calver same month", VersionSchemaCalVer, "~2021.08", "2021.08.5", true},
+		{"tilde: calver next month", VersionSchemaCalVer, "~2021.08", "2021.09", false},
+		{"caret: calver same year", VersionSchemaCalVer, "^2021.08", "2021.12.1", true},
+		{"caret: calver next year", VersionSchemaCalVer, "^2021.08", "2022.01", false},
+
+		{"alternatives: first", VersionSchemaSemVer, "^1.4 || ^3.1", "1.5.0", true},
+		{"alternatives: second", VersionSchemaSemVer, "^1.4 || ^3.1", "3.2.0", true},
+		{"alternatives: none", VersionSchemaSemVer, "^1.4 || ^3.1", "2.0.0", false},
+
+		{"date: range", VersionSchemaDate, ">=2021-08-01 <2021.09.01", "20210830", true},
+		{"free-form: tilde", VersionSchemaFreeForm, "~r1.4", "r1.4.7", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseVersionConstraint(tt.constraint, tt.schema)
+			if err != nil {
+				t.Fatalf("ParseVersionConstraint() error = %v", err)
+			}
+			v, err := ParseVersion(tt.version, tt.schema)
+			if err != nil {
+				t.Fatalf("ParseVersion() error = %v", err)
+			}
+			if got := c.Satisfies(v); got != tt.want {
+				t.Errorf("Satisfies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraint(t *testing.T) {
+	registerVersionSchema(t, "unordered", VersionSchemaFunc(func(name string) error { return nil }))
+
+	tests := []struct {
+		name       string
+		schema     string
+		constraint string
+		wantErr    bool
+	}{
+		{"is empty", VersionSchemaSemVer, " ", true},
+		{"has empty alternative", VersionSchemaSemVer, "^1.0 ||", true},
+		{"has dangling operator", VersionSchemaSemVer, "^1.0 >=", true},
+		{"has double operator", VersionSchemaSemVer, ">=<1.0.0", true},
+		{"has invalid version", VersionSchemaSemVer, ">=1.0.0.0", true},
+		{"has unknown schema", "unknown", "1.0.0", true},
+		{"has schema without parser", "unordered", "1.0.0", true},
+		{"has date with caret", VersionSchemaDate, "^2021-08-30", false},
+		{"is valid", VersionSchemaSemVer, ">=1.2.0 <2.0.0 || ^3 || 4.0.0-rc.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseVersionConstraint(tt.constraint, tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseVersionConstraint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVersionConstraint_BestMatch(t *testing.T) {
+	c, err := (&ModuleDependency{Version: "^1.4"}).VersionConstraint(VersionSchemaSemVer)
+	if err != nil {
+		t.Fatalf("VersionConstraint() error = %v", err)
+	}
+
+	var candidates []Version
+	for _, name := range []string{"1.3.0", "1.10.0", "2.0.0", "1.4.1", "1.9.0"} {
+		v, err := ParseVersion(name, VersionSchemaSemVer)
+		if err != nil {
+			t.Fatalf("ParseVersion() error = %v", err)
+		}
+		candidates = append(candidates, v)
+	}
+
+	got, ok := c.BestMatch(candidates)
+	if !ok || got.String() != "1.10.0" {
+		t.Errorf("BestMatch() = %v, %v, want 1.10.0, true", got, ok)
+	}
+
+	if _, ok := c.BestMatch(candidates[2:3]); ok {
+		t.Errorf("BestMatch() = true, want false")
+	}
+}
+
+func TestModuleDependency_ValidateWithOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		opts    ValidationOptions
+		wantErr bool
+	}{
+		{"constraint without opt-in", ">=1.2.0 <2.0.0", ValidationOptions{}, true},
+		{"constraint with opt-in", ">=1.2.0 <2.0.0", ValidationOptions{AllowVersionConstraints: true}, false},
+		{"caret with opt-in", "^1.4 || ~2.1", ValidationOptions{AllowVersionConstraints: true}, false},
+		{"exact version with opt-in", "v1.0.0", ValidationOptions{AllowVersionConstraints: true}, false},
+		{"invalid constraint with opt-in", ">=", ValidationOptions{AllowVersionConstraints: true}, true},
+		{"invalid version in constraint with opt-in", ">=V1", ValidationOptions{AllowVersionConstraints: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &ModuleDependency{Namespace: "com.example", Name: "product", Type: "go", Version: tt.version}
+			if err := x.ValidateWithOptions(tt.opts); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			m := &Module{Namespace: "com.example", Name: "app", Type: "go", Version: &ModuleVersion{Name: "v1.0.0"}, Dependencies: []*ModuleDependency{x}}
+			if err := m.ValidateWithOptions(tt.opts); (err != nil) != tt.wantErr {
+				t.Errorf("Module.ValidateWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
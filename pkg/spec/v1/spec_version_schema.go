@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	// VersionSchemaFreeForm accepts every version name fulfilling the general version name constraints.
+	// It is used to order versions of modules without a version schema.
+	VersionSchemaFreeForm = "free-form"
+	// VersionSchemaSemVer accepts semantic versions like 1.2.3 or v1.2.3-rc.1, see https://semver.org.
+	VersionSchemaSemVer = "semver"
+	// VersionSchemaCalVer accepts calendar versions like 2021.08, 21.8.1 or 2021.08.30-hotfix, see https://calver.org.
+	VersionSchemaCalVer = "calver"
+	// VersionSchemaDate accepts dates like 20210830, 2021-08-30 or 2021.08.30.
+	VersionSchemaDate = "date"
+)
+
+// VersionSchema checks if module version names follow a version schema.
+type VersionSchema interface {
+	// Validate returns an error if the version name does not follow the version schema.
+	Validate(name string) error
+}
+
+// VersionSchemaFunc is an adapter to use ordinary functions as VersionSchema.
+type VersionSchemaFunc func(name string) error
+
+// Validate calls f(name).
+func (f VersionSchemaFunc) Validate(name string) error {
+	return f(name)
+}
+
+// VersionParser is implemented by version schemas whose version names can be parsed into orderable versions.
+type VersionParser interface {
+	// ParseKey parses the version name into its orderable representation.
+	ParseKey(name string) (VersionKey, error)
+}
+
+// VersionParserFunc is an adapter to use ordinary parse functions as VersionSchema and VersionParser.
+type VersionParserFunc func(name string) (VersionKey, error)
+
+// Validate returns the error of f(name).
+func (f VersionParserFunc) Validate(name string) error {
+	_, err := f(name)
+	return err
+}
+
+// ParseKey calls f(name).
+func (f VersionParserFunc) ParseKey(name string) (VersionKey, error) {
+	return f(name)
+}
+
+var (
+	versionSchemasMu sync.RWMutex
+	versionSchemas   = map[string]VersionSchema{
+		VersionSchemaFreeForm: VersionParserFunc(parseFreeFormVersion),
+		VersionSchemaSemVer:   VersionParserFunc(parseSemVerVersion),
+		VersionSchemaCalVer:   VersionParserFunc(parseCalVerVersion),
+		VersionSchemaDate:     VersionParserFunc(parseDateVersion),
+	}
+)
+
+// RegisterVersionSchema makes a version schema available under the given name.
+// It panics if the name does not fulfil the schema constraints, the schema is nil
+// or a schema with the same name is already registered.
+func RegisterVersionSchema(name string, schema VersionSchema) {
+	if err := validateModuleVersionSchema(name); err != nil {
+		panic(fmt.Sprintf("register version schema %q: %v", name, err))
+	}
+	if schema == nil {
+		panic(fmt.Sprintf("register version schema %q: schema is nil", name))
+	}
+
+	versionSchemasMu.Lock()
+	defer versionSchemasMu.Unlock()
+
+	if _, exists := versionSchemas[name]; exists {
+		panic(fmt.Sprintf("register version schema %q: already registered", name))
+	}
+	versionSchemas[name] = schema
+}
+
+// unregisterVersionSchema removes the version schema registered under the given name.
+// It allows tests to undo their registrations.
+func unregisterVersionSchema(name string) {
+	versionSchemasMu.Lock()
+	defer versionSchemasMu.Unlock()
+
+	delete(versionSchemas, name)
+}
+
+// LookupVersionSchema returns the version schema registered under the given name.
+func LookupVersionSchema(name string) (VersionSchema, bool) {
+	versionSchemasMu.RLock()
+	defer versionSchemasMu.RUnlock()
+
+	schema, ok := versionSchemas[name]
+	return schema, ok
+}
+
+// VersionSchemas returns the sorted names of all registered version schemas.
+func VersionSchemas() []string {
+	versionSchemasMu.RLock()
+	defer versionSchemasMu.RUnlock()
+
+	names := make([]string, 0, len(versionSchemas))
+	for name := range versionSchemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// registerVersionSchema registers the version schema for the duration of the test.
+func registerVersionSchema(t *testing.T, name string, schema VersionSchema) {
+	t.Helper()
+	RegisterVersionSchema(name, schema)
+	t.Cleanup(func() { unregisterVersionSchema(name) })
+}
+
+func TestRegisterVersionSchema(t *testing.T) {
+	registerVersionSchema(t, "even-length", VersionSchemaFunc(func(name string) error {
+		if len(name)%2 != 0 {
+			return errors.New("must have an even length")
+		}
+		return nil
+	}))
+
+	if _, ok := LookupVersionSchema("even-length"); !ok {
+		t.Fatalf("LookupVersionSchema() = false, want true")
+	}
+	if got := strings.Join(VersionSchemas(), ","); got != "calver,date,even-length,free-form,semver" {
+		t.Errorf("VersionSchemas() = %v", got)
+	}
+
+	schema := "even-length"
+	tests := []struct {
+		name    string
+		version string
+		wantErr bool
+	}{
+		{"follows schema", "v1", false},
+		{"does not follow schema", "v10", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &ModuleVersion{Name: tt.version, Schema: &schema}
+			if err := x.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterVersionSchema_panics(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		impl   VersionSchema
+	}{
+		{"has invalid name", "Invalid", VersionParserFunc(parseFreeFormVersion)},
+		{"is nil", "nil-schema", nil},
+		{"is already registered", VersionSchemaSemVer, VersionParserFunc(parseFreeFormVersion)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterVersionSchema() did not panic")
+				}
+			}()
+			RegisterVersionSchema(tt.schema, tt.impl)
+		})
+	}
+}
+
+func TestModuleVersion_Validate_schemaRules(t *testing.T) {
+	semver := VersionSchemaSemVer
+	unknown := "unknown"
+
+	tests := []struct {
+		name      string
+		version   *ModuleVersion
+		wantField string
+		wantRule  ValidationRule
+	}{
+		{"has name not following schema", &ModuleVersion{Name: "2021-08-30", Schema: &semver}, "name", RuleFormat},
+		{"has replaces entry not following schema", &ModuleVersion{Name: "1.1.0", Schema: &semver, Replaces: []string{"1.0"}}, "replaces[0]", RuleFormat},
+		{"has unknown schema", &ModuleVersion{Name: "1.1.0", Schema: &unknown}, "schema", RuleUnknownSchema},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *ValidationError
+			if err := tt.version.Validate(); !errors.As(err, &got) {
+				t.Fatalf("Validate() error = %v, want ValidationError", err)
+			}
+			if got.Field != tt.wantField || got.Rule != tt.wantRule {
+				t.Errorf("Validate() error = %+v, want field %q and rule %q", got, tt.wantField, tt.wantRule)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_parseSemVerVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		wantErr bool
+	}{
+		{"is empty", "", true},
+		{"is plain version", "1.0.0", false},
+		{"has prefix v", "v1.0.0", false},
+		{"has prerelease", "1.0.0-rc.1", false},
+		{"has build metadata", "1.0.0+build.5", false},
+		{"has prerelease and build metadata", "v1.0.0-alpha+001", false},
+		{"misses patch", "1.0", true},
+		{"has leading zero", "1.01.0", true},
+		{"has leading zero in numeric prerelease", "1.0.0-01", true},
+		{"has empty prerelease identifier", "1.0.0-rc..1", true},
+		{"is date", "2021-08-30", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseSemVerVersion(tt.version); (err != nil) != tt.wantErr {
+				t.Errorf("parseSemVerVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_parseCalVerVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		wantErr bool
+	}{
+		{"is empty", "", true},
+		{"is year and month", "2021.08", false},
+		{"is short year and month", "21.8", false},
+		{"has micro", "2021.08.1", false},
+		{"has day", "2021.08.30", false},
+		{"has modifier", "2021.08.30-hotfix.1", false},
+		{"has invalid month", "2021.13", true},
+		{"has zero month", "2021.0", true},
+		{"has three digit year", "202.08", true},
+		{"misses month", "2021", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseCalVerVersion(tt.version); (err != nil) != tt.wantErr {
+				t.Errorf("parseCalVerVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_parseDateVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		wantErr bool
+	}{
+		{"is empty", "", true},
+		{"is date", "20210830", false},
+		{"is dashed date", "2021-08-30", false},
+		{"is dotted date", "2021.08.30", false},
+		{"has invalid month", "2021-13-30", true},
+		{"has invalid day", "2021-02-30", true},
+		{"has mixed separators", "2021-08.30", true},
+		{"is semantic version", "1.0.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseDateVersion(tt.version); (err != nil) != tt.wantErr {
+				t.Errorf("parseDateVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		a      string
+		b      string
+		want   int
+	}{
+		{"semver: equal", VersionSchemaSemVer, "1.0.0", "1.0.0", 0},
+		{"semver: prefix v is ignored", VersionSchemaSemVer, "v1.0.0", "1.0.0", 0},
+		{"semver: major", VersionSchemaSemVer, "1.9.9", "2.0.0", -1},
+		{"semver: minor", VersionSchemaSemVer, "1.10.0", "1.9.0", 1},
+		{"semver: patch", VersionSchemaSemVer, "1.0.1", "1.0.2", -1},
+		{"semver: prerelease is less than release", VersionSchemaSemVer, "1.0.0-rc.1", "1.0.0", -1},
+		{"semver: numeric prerelease identifiers", VersionSchemaSemVer, "1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"semver: numeric is less than alphanumeric identifier", VersionSchemaSemVer, "1.0.0-1", "1.0.0-alpha", -1},
+		{"semver: alphanumeric identifiers", VersionSchemaSemVer, "1.0.0-beta", "1.0.0-alpha", 1},
+		{"semver: fewer identifiers are less", VersionSchemaSemVer, "1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"semver: build metadata is ignored", VersionSchemaSemVer, "1.0.0+build.1", "1.0.0+build.2", 0},
+
+		{"calver: equal", VersionSchemaCalVer, "2021.08", "2021.8", 0},
+		{"calver: short year", VersionSchemaCalVer, "21.08", "2021.08", 0},
+		{"calver: year", VersionSchemaCalVer, "2020.12", "2021.01", -1},
+		{"calver: month", VersionSchemaCalVer, "2021.10", "2021.09", 1},
+		{"calver: missing micro is less", VersionSchemaCalVer, "2021.08", "2021.08.0", -1},
+		{"calver: micro", VersionSchemaCalVer, "2021.08.2", "2021.08.10", -1},
+		{"calver: modifier is less", VersionSchemaCalVer, "2021.08.1-dev", "2021.08.1", -1},
+
+		{"date: equal in different layouts", VersionSchemaDate, "20210830", "2021-08-30", 0},
+		{"date: dotted and dashed", VersionSchemaDate, "2021.08.31", "2021-08-30", 1},
+		{"date: day", VersionSchemaDate, "2021-08-01", "2021-08-30", -1},
+
+		{"free-form: equal", VersionSchemaFreeForm, "release-1", "release-1", 0},
+		{"free-form: numeric", VersionSchemaFreeForm, "release-9", "release-10", -1},
+		{"free-form: prefix v is ignored", VersionSchemaFreeForm, "v1.10", "1.9", 1},
+		{"free-form: longer is greater", VersionSchemaFreeForm, "1.0", "1.0.1", -1},
+		{"free-form: same natural order", VersionSchemaFreeForm, "1.01", "1.1", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseVersion(tt.a, tt.schema)
+			if err != nil {
+				t.Fatalf("ParseVersion() error = %v", err)
+			}
+			b, err := ParseVersion(tt.b, tt.schema)
+			if err != nil {
+				t.Fatalf("ParseVersion() error = %v", err)
+			}
+
+			if got := a.Compare(b); got != tt.want {
+				t.Errorf("Compare() = %d, want %d", got, tt.want)
+			}
+			if got := b.Compare(a); got != -tt.want {
+				t.Errorf("reversed Compare() = %d, want %d", got, -tt.want)
+			}
+			if got := a.Less(b); got != (tt.want < 0) {
+				t.Errorf("Less() = %v, want %v", got, tt.want < 0)
+			}
+			if got := a.Equal(b); got != (tt.want == 0) {
+				t.Errorf("Equal() = %v, want %v", got, tt.want == 0)
+			}
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	registerVersionSchema(t, "validation-only", VersionSchemaFunc(func(name string) error { return nil }))
+
+	tests := []struct {
+		name    string
+		version string
+		schema  string
+		wantErr bool
+	}{
+		{"is valid", "v1.0.0", VersionSchemaSemVer, false},
+		{"does not follow schema", "2021-08-30", VersionSchemaSemVer, true},
+		{"has unknown schema", "v1.0.0", "unknown", true},
+		{"has schema without parser", "v1.0.0", "validation-only", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVersion(tt.version, tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.String() != tt.version || got.Schema() != tt.schema) {
+				t.Errorf("ParseVersion() = %s (%s), want %s (%s)", got, got.Schema(), tt.version, tt.schema)
+			}
+		})
+	}
+}
+
+func TestModuleVersion_Parse(t *testing.T) {
+	semver := VersionSchemaSemVer
+
+	tests := []struct {
+		name       string
+		version    *ModuleVersion
+		wantSchema string
+		wantErr    bool
+	}{
+		{"has schema", &ModuleVersion{Name: "1.0.0", Schema: &semver}, VersionSchemaSemVer, false},
+		{"has no schema", &ModuleVersion{Name: "1.0"}, VersionSchemaFreeForm, false},
+		{"does not follow schema", &ModuleVersion{Name: "1.0", Schema: &semver}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.version.Parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Schema() != tt.wantSchema {
+				t.Errorf("Parse() schema = %q, want %q", got.Schema(), tt.wantSchema)
+			}
+		})
+	}
+}
+
+func TestSortModulesByVersion(t *testing.T) {
+	semver := VersionSchemaSemVer
+	module := func(version string) *Module {
+		return &Module{Version: &ModuleVersion{Name: version, Schema: &semver}}
+	}
+	names := func(modules []*Module) string {
+		var versions []string
+		for _, m := range modules {
+			versions = append(versions, m.GetVersion().GetName())
+		}
+		return strings.Join(versions, ",")
+	}
+
+	modules := []*Module{module("v1.10.0"), module("1.0.0"), module("1.0.0-rc.1"), module("v1.2.0")}
+	if err := SortModulesByVersion(modules); err != nil {
+		t.Fatalf("SortModulesByVersion() error = %v", err)
+	}
+	if got, want := names(modules), "1.0.0-rc.1,1.0.0,v1.2.0,v1.10.0"; got != want {
+		t.Errorf("SortModulesByVersion() = %s, want %s", got, want)
+	}
+
+	invalid := []*Module{module("1.0.0"), module("1.0")}
+	if err := SortModulesByVersion(invalid); err == nil {
+		t.Errorf("SortModulesByVersion() error = nil, want error")
+	}
+	if got, want := names(invalid), "1.0.0,1.0"; got != want {
+		t.Errorf("SortModulesByVersion() modified order to %s, want %s", got, want)
+	}
+
+	if err := SortModulesByVersion([]*Module{{}}); err == nil {
+		t.Errorf("SortModulesByVersion() error = nil, want error for missing version")
+	}
+}
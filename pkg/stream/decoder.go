@@ -0,0 +1,192 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// DecoderOptions configures the decoding of records.
+type DecoderOptions struct {
+	// Validate validates each decoded module according to ValidationOptions.
+	Validate bool
+	// ValidationOptions configures the validation of the decoded modules.
+	ValidationOptions v1.ValidationOptions
+	// DiscardUnknown ignores unknown fields of NDJSON records instead of rejecting them.
+	DiscardUnknown bool
+	// MaxRecordSize is the maximum size of a record in bytes. It defaults to DefaultMaxRecordSize.
+	MaxRecordSize int
+}
+
+// Decoder reads modules as records from a stream.
+type Decoder struct {
+	r      *bufio.Reader
+	format Format
+	opts   DecoderOptions
+	index  int
+	offset int64
+	// err is the sticky error after which the record boundaries are unknown.
+	err error
+}
+
+// NewDecoder returns a decoder reading records in the format from r.
+func NewDecoder(r io.Reader, format Format, opts DecoderOptions) *Decoder {
+	if opts.MaxRecordSize <= 0 {
+		opts.MaxRecordSize = DefaultMaxRecordSize
+	}
+	return &Decoder{r: bufio.NewReader(r), format: format, opts: opts}
+}
+
+// Decode reads the next record and returns its module or io.EOF at the end of the stream.
+//
+// Errors of a record are returned as *RecordError. If the record boundaries are intact,
+// e.g. if the record cannot be unmarshalled or is not valid, the next call continues with the next record.
+// If the module is not valid, it is returned together with the error. Otherwise, e.g. if the
+// stream is truncated, the error is returned by all subsequent calls as well.
+func (d *Decoder) Decode() (*v1.Module, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	index := d.index
+	data, offset, err := d.next()
+	if err == io.EOF {
+		d.err = io.EOF
+		return nil, io.EOF
+	}
+	d.index++
+	if err != nil {
+		recordErr := &RecordError{Index: index, Offset: offset, Err: err}
+		if !errors.Is(err, errRecordTooLarge) || d.format != FormatNDJSON {
+			d.err = recordErr
+		}
+		return nil, recordErr
+	}
+
+	module := &v1.Module{}
+	switch d.format {
+	case FormatNDJSON:
+		err = module.UnmarshalJSONWithOptions(data, v1.JSONUnmarshalOptions{DiscardUnknown: d.opts.DiscardUnknown})
+	default:
+		err = proto.Unmarshal(data, module)
+	}
+	if err != nil {
+		return nil, &RecordError{Index: index, Offset: offset, Err: err}
+	}
+
+	if d.opts.Validate {
+		if err := module.ValidateWithOptions(d.opts.ValidationOptions); err != nil {
+			return module, &RecordError{Index: index, Offset: offset, Err: err}
+		}
+	}
+
+	return module, nil
+}
+
+// errRecordTooLarge reports a record exceeding the maximum record size.
+var errRecordTooLarge = errors.New("record exceeds the maximum record size")
+
+// next reads the data of the next record and returns its offset.
+// It returns io.EOF if the stream ends before the record starts.
+func (d *Decoder) next() ([]byte, int64, error) {
+	switch d.format {
+	case FormatDelimited:
+		offset := d.offset
+		data, err := d.nextDelimited()
+		return data, offset, err
+	case FormatNDJSON:
+		return d.nextLine()
+	default:
+		return nil, d.offset, fmt.Errorf("unsupported format %v", d.format)
+	}
+}
+
+func (d *Decoder) nextDelimited() ([]byte, error) {
+	counter := &byteCounter{r: d.r}
+	size, err := binary.ReadUvarint(counter)
+	d.offset += counter.n
+	if err != nil {
+		if err == io.EOF && counter.n > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if size > uint64(d.opts.MaxRecordSize) {
+		return nil, fmt.Errorf("%w: size %d exceeds %d bytes", errRecordTooLarge, size, d.opts.MaxRecordSize)
+	}
+
+	data := make([]byte, size)
+	n, err := io.ReadFull(d.r, data)
+	d.offset += int64(n)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return data, err
+}
+
+// nextLine reads the next non-blank line and returns its offset.
+// Lines exceeding the maximum record size without their line terminator are skipped entirely.
+func (d *Decoder) nextLine() ([]byte, int64, error) {
+	for {
+		offset := d.offset
+		var line []byte
+		tooLarge := false
+		for {
+			chunk, err := d.r.ReadSlice('\n')
+			d.offset += int64(len(chunk))
+			if !tooLarge {
+				if len(line)+len(chunk) > d.opts.MaxRecordSize+len("\r\n") {
+					tooLarge, line = true, nil
+				} else {
+					line = append(line, chunk...)
+				}
+			}
+			if err == bufio.ErrBufferFull {
+				continue
+			}
+			if err != nil && (err != io.EOF || (len(line) == 0 && !tooLarge)) {
+				return nil, offset, err
+			}
+			break
+		}
+
+		if !tooLarge && len(trimLineTerminator(line)) > d.opts.MaxRecordSize {
+			tooLarge = true
+		}
+		if tooLarge {
+			return nil, offset, fmt.Errorf("%w of %d bytes", errRecordTooLarge, d.opts.MaxRecordSize)
+		}
+		if trimmed := bytes.TrimLeft(line, " \t\r\n"); len(trimmed) > 0 {
+			return bytes.TrimRight(trimmed, " \t\r\n"), offset + int64(len(line)-len(trimmed)), nil
+		}
+	}
+}
+
+// trimLineTerminator returns the line without a trailing \n or \r\n.
+func trimLineTerminator(line []byte) []byte {
+	if !bytes.HasSuffix(line, []byte("\n")) {
+		return line
+	}
+	return bytes.TrimSuffix(line[:len(line)-1], []byte("\r"))
+}
+
+// byteCounter counts the bytes read.
+type byteCounter struct {
+	r io.ByteReader
+	n int64
+}
+
+func (c *byteCounter) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
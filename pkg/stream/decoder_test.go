@@ -0,0 +1,205 @@
+package stream
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func catalog(n int) []*v1.Module {
+	schema := "semver"
+	modules := make([]*v1.Module, n)
+	for i := range modules {
+		modules[i] = &v1.Module{
+			Namespace:   "com.example",
+			Name:        fmt.Sprintf("module-%d", i),
+			Type:        "go",
+			Version:     &v1.ModuleVersion{Name: "v1.0.0", Schema: &schema},
+			Annotations: map[string]string{"com.example.index": fmt.Sprint(i)},
+		}
+		if i > 0 {
+			modules[i].Dependencies = []*v1.ModuleDependency{
+				{Namespace: "com.example", Name: fmt.Sprintf("module-%d", i-1), Type: "go", Version: "v1.0.0"},
+			}
+		}
+	}
+	return modules
+}
+
+func TestDecoder_Decode_roundTrip(t *testing.T) {
+	modules := catalog(1000)
+	for _, format := range []Format{FormatDelimited, FormatNDJSON} {
+		t.Run(format.String(), func(t *testing.T) {
+			var b bytes.Buffer
+			e := NewEncoder(&b, format)
+			for _, module := range modules {
+				if err := e.Encode(module); err != nil {
+					t.Fatalf("Encode() error = %v", err)
+				}
+			}
+
+			d := NewDecoder(&b, format, DecoderOptions{Validate: true})
+			for i, want := range modules {
+				got, err := d.Decode()
+				if err != nil {
+					t.Fatalf("Decode() of record %d error = %v", i, err)
+				}
+				if !proto.Equal(got, want) {
+					t.Fatalf("Decode() of record %d = %v, want %v", i, got, want)
+				}
+			}
+			if _, err := d.Decode(); err != io.EOF {
+				t.Errorf("Decode() error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestDecoder_Decode_ndjson(t *testing.T) {
+	data := `{"namespace":"com.example","name":"a","type":"go","version":{"name":"v1.0.0"}}
+
+{"name":"b","owner":"me"}
+not json
+{"namespace":"com.example","name":"Invalid","type":"go","version":{"name":"v1.0.0"}}
+  {"namespace":"com.example","name":"c","type":"go","version":{"name":"v1.0.0"}}`
+
+	type result struct {
+		name   string
+		index  int
+		offset int64
+		err    bool
+	}
+	tests := []struct {
+		name string
+		opts DecoderOptions
+		want []result
+	}{
+		{"is strict", DecoderOptions{}, []result{
+			{"a", 0, 0, false},
+			{"", 1, 80, true},
+			{"", 2, 106, true},
+			{"Invalid", 3, 115, false},
+			{"c", 4, 202, false},
+		}},
+		{"validates", DecoderOptions{Validate: true, DiscardUnknown: true}, []result{
+			{"a", 0, 0, false},
+			{"b", 1, 80, true},
+			{"", 2, 106, true},
+			{"Invalid", 3, 115, true},
+			{"c", 4, 202, false},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDecoder(strings.NewReader(data), FormatNDJSON, tt.opts)
+			for _, want := range tt.want {
+				module, err := d.Decode()
+				if got := module.GetName(); got != want.name {
+					t.Errorf("Decode() of record %d = %q, want %q", want.index, got, want.name)
+				}
+				if (err != nil) != want.err {
+					t.Fatalf("Decode() of record %d error = %v, wantErr %v", want.index, err, want.err)
+				}
+				if err == nil {
+					continue
+				}
+				var recordErr *RecordError
+				if !errors.As(err, &recordErr) {
+					t.Fatalf("Decode() error = %v, want *RecordError", err)
+				}
+				if recordErr.Index != want.index || recordErr.Offset != want.offset {
+					t.Errorf("Decode() error at record %d offset %d, want record %d offset %d", recordErr.Index, recordErr.Offset, want.index, want.offset)
+				}
+			}
+			if _, err := d.Decode(); err != io.EOF {
+				t.Errorf("Decode() error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestDecoder_Decode_errors(t *testing.T) {
+	var b bytes.Buffer
+	e := NewEncoder(&b, FormatDelimited)
+	for _, module := range catalog(2) {
+		if err := e.Encode(module); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+	}
+	first := b.Len() - proto.Size(catalog(2)[1]) - 1
+	valid := b.Bytes()
+
+	tests := []struct {
+		name       string
+		format     Format
+		data       []byte
+		opts       DecoderOptions
+		wantErr    error
+		wantOffset int64
+		wantSticky bool
+	}{
+		{"is truncated", FormatDelimited, valid[:len(valid)-3], DecoderOptions{}, io.ErrUnexpectedEOF, int64(first), true},
+		{"is truncated in length", FormatDelimited, append(valid[:first:first], 0x80), DecoderOptions{}, io.ErrUnexpectedEOF, int64(first), true},
+		{"exceeds the maximum record size", FormatDelimited, valid, DecoderOptions{MaxRecordSize: first - 1}, errRecordTooLarge, int64(first), true},
+		{"exceeds the maximum line size", FormatNDJSON, []byte("{}\n{\"name\":\"too-large\"}\n{}\n"), DecoderOptions{MaxRecordSize: 8}, errRecordTooLarge, 3, false},
+		{"has invalid record", FormatDelimited, append(valid[:first:first], 0x02, 0xff, 0xff), DecoderOptions{}, nil, int64(first), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDecoder(bytes.NewReader(tt.data), tt.format, tt.opts)
+			if _, err := d.Decode(); err != nil {
+				t.Fatalf("Decode() of first record error = %v", err)
+			}
+
+			_, err := d.Decode()
+			var recordErr *RecordError
+			if !errors.As(err, &recordErr) {
+				t.Fatalf("Decode() error = %v, want *RecordError", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+			if recordErr.Index != 1 || recordErr.Offset != tt.wantOffset {
+				t.Errorf("Decode() error at record %d offset %d, want record 1 offset %d", recordErr.Index, recordErr.Offset, tt.wantOffset)
+			}
+
+			_, next := d.Decode()
+			if sticky := next == err; sticky != tt.wantSticky {
+				t.Errorf("Decode() after error = %v, want sticky %v", next, tt.wantSticky)
+			}
+		})
+	}
+}
+
+func TestDecoder_Decode_maxRecordSize(t *testing.T) {
+	record := `{"name":"a"}`
+	tests := []struct {
+		name    string
+		data    string
+		size    int
+		wantErr bool
+	}{
+		{"has maximum size with LF", record + "\n", len(record), false},
+		{"has maximum size with CRLF", record + "\r\n", len(record), false},
+		{"has maximum size at end of stream", record, len(record), false},
+		{"exceeds maximum size with LF", record + "\n", len(record) - 1, true},
+		{"exceeds maximum size with CRLF", record + "\r\n", len(record) - 1, true},
+		{"exceeds maximum size with CR at end of stream", record + "\r", len(record), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDecoder(strings.NewReader(tt.data), FormatNDJSON, DecoderOptions{MaxRecordSize: tt.size})
+			_, err := d.Decode()
+			if tt.wantErr != errors.Is(err, errRecordTooLarge) {
+				t.Errorf("Decode() error = %v, want too large %v", err, tt.wantErr)
+			}
+		})
+	}
+}
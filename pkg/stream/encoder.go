@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+// Encoder writes modules as records to a stream.
+type Encoder struct {
+	w      io.Writer
+	format Format
+	index  int
+	offset int64
+}
+
+// NewEncoder returns an encoder writing records in the format to w.
+// Each record is written with a single call to w, so w may be buffered by the caller.
+func NewEncoder(w io.Writer, format Format) *Encoder {
+	return &Encoder{w: w, format: format}
+}
+
+// Encode writes the module as next record. A *RecordError is returned if the module cannot be encoded or written.
+func (e *Encoder) Encode(module *v1.Module) error {
+	record, err := e.record(module)
+	if err == nil {
+		var n int
+		n, err = e.w.Write(record)
+		if err == nil && n < len(record) {
+			err = io.ErrShortWrite
+		}
+	}
+	if err != nil {
+		return &RecordError{Index: e.index, Offset: e.offset, Err: err}
+	}
+
+	e.index++
+	e.offset += int64(len(record))
+	return nil
+}
+
+func (e *Encoder) record(module *v1.Module) ([]byte, error) {
+	if module == nil {
+		return nil, errors.New("module must not be nil")
+	}
+
+	switch e.format {
+	case FormatDelimited:
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(module)
+		if err != nil {
+			return nil, err
+		}
+		record := protowire.AppendVarint(make([]byte, 0, protowire.SizeVarint(uint64(len(data)))+len(data)), uint64(len(data)))
+		return append(record, data...), nil
+	case FormatNDJSON:
+		data, err := module.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %v", e.format)
+	}
+}
@@ -0,0 +1,59 @@
+package stream
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	v1 "github.com/opendependency/go-spec/pkg/spec/v1"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	modules := []*v1.Module{
+		{Namespace: "com.example", Name: "a", Type: "go"},
+		{Name: "b", Dependencies: []*v1.ModuleDependency{{Name: "a", Direction: v1.DependencyDirection_DOWNSTREAM.Enum()}}},
+	}
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{"is delimited", FormatDelimited, "\x14\x0a\x0bcom.example\x12\x01a\x1a\x02go" + "\x0a\x12\x01b\x32\x05\x12\x01a\x28\x01"},
+		{"is ndjson", FormatNDJSON, `{"namespace":"com.example","name":"a","type":"go"}` + "\n" + `{"name":"b","dependencies":[{"name":"a","direction":"DOWNSTREAM"}]}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			e := NewEncoder(&b, tt.format)
+			for _, module := range modules {
+				if err := e.Encode(module); err != nil {
+					t.Fatalf("Encode() error = %v", err)
+				}
+			}
+			if b.String() != tt.want {
+				t.Errorf("Encode() = %q, want %q", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoder_Encode_errors(t *testing.T) {
+	var b bytes.Buffer
+	e := NewEncoder(&b, FormatNDJSON)
+	if err := e.Encode(&v1.Module{Name: "a"}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	err := e.Encode(nil)
+	var recordErr *RecordError
+	if !errors.As(err, &recordErr) {
+		t.Fatalf("Encode() error = %v, want *RecordError", err)
+	}
+	if recordErr.Index != 1 || recordErr.Offset != 13 {
+		t.Errorf("Encode() error at record %d offset %d, want record 1 offset 13", recordErr.Index, recordErr.Offset)
+	}
+
+	if err := NewEncoder(&b, Format(7)).Encode(&v1.Module{}); err == nil {
+		t.Errorf("Encode() error = nil, want unsupported format")
+	}
+}
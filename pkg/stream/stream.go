@@ -0,0 +1,55 @@
+// Package stream reads and writes catalogs of modules as a stream of records, one module at a time.
+//
+// Two container formats are supported. FormatDelimited is a sequence of binary protobuf records,
+// each prefixed by its length in bytes as unsigned varint. FormatNDJSON is a sequence of lines,
+// each containing a module in the proto3 JSON mapping, see https://github.com/ndjson/ndjson-spec.
+package stream
+
+import (
+	"fmt"
+)
+
+// Format is a container format.
+type Format int
+
+const (
+	// FormatDelimited is a sequence of length-delimited binary protobuf records.
+	FormatDelimited Format = iota
+	// FormatNDJSON is a sequence of newline-delimited JSON records.
+	FormatNDJSON
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatDelimited:
+		return "delimited"
+	case FormatNDJSON:
+		return "ndjson"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// DefaultMaxRecordSize is the maximum size of a record in bytes, if not configured otherwise.
+const DefaultMaxRecordSize = 4 << 20
+
+// RecordError reports an error of a single record in the stream.
+type RecordError struct {
+	// Index is the 0-based index of the record in the stream.
+	Index int
+	// Offset is the byte offset of the record in the stream.
+	Offset int64
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the record position followed by the underlying error.
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d at offset %d: %v", e.Index, e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
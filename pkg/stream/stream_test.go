@@ -0,0 +1,35 @@
+package stream
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFormat_String(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatDelimited, "delimited"},
+		{FormatNDJSON, "ndjson"},
+		{Format(7), "Format(7)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.format.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	err := error(&RecordError{Index: 2, Offset: 120, Err: io.ErrUnexpectedEOF})
+	if got, want := err.Error(), "record 2 at offset 120: unexpected EOF"; got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("errors.Is() = false, want true")
+	}
+}